@@ -0,0 +1,12 @@
+package main
+
+import (
+	"os"
+
+	"basanos/internal/assert"
+)
+
+func main() {
+	os.Exit(assert.RunCLI(os.Args[1:], os.Stdin, os.Stdout,
+		assert.ResolveBothValues, assert.JSONEquals))
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type schemaChecker struct {
+	root   map[string]interface{}
+	errors []string
+}
+
+func CheckDocument(schema map[string]interface{}, data []byte) ([]string, error) {
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	checker := &schemaChecker{root: schema}
+	checker.check(schema, document, "")
+	return checker.errors, nil
+}
+
+func (checker *schemaChecker) addError(path, message string) {
+	if path == "" {
+		path = "(root)"
+	}
+	checker.errors = append(checker.errors, fmt.Sprintf("%s: %s", path, message))
+}
+
+func (checker *schemaChecker) resolve(schema map[string]interface{}) map[string]interface{} {
+	ref, ok := schema["$ref"].(string)
+	if !ok {
+		return schema
+	}
+	defs, _ := checker.root["$defs"].(map[string]interface{})
+	resolved, _ := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]interface{})
+	return checker.resolve(resolved)
+}
+
+func (checker *schemaChecker) check(schema map[string]interface{}, value interface{}, path string) {
+	schema = checker.resolve(schema)
+	if schema == nil {
+		return
+	}
+	if allowed, ok := schema["type"]; ok && !matchesType(allowed, value) {
+		checker.addError(path, fmt.Sprintf("expected %v, got %s", allowed, jsonType(value)))
+		return
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && !containsValue(enum, value) {
+		checker.addError(path, fmt.Sprintf("must be one of %v", enum))
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if text, isString := value.(string); isString && !regexp.MustCompile(pattern).MatchString(text) {
+			checker.addError(path, fmt.Sprintf("does not match %s", pattern))
+		}
+	}
+	if minimum, ok := schema["minimum"].(float64); ok {
+		if number, isInt := value.(int); isInt && float64(number) < minimum {
+			checker.addError(path, fmt.Sprintf("must be at least %v", minimum))
+		}
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		checker.checkObject(schema, typed, path)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for index, item := range typed {
+				checker.check(items, item, fmt.Sprintf("%s[%d]", path, index))
+			}
+		}
+	}
+}
+
+func (checker *schemaChecker) checkObject(schema map[string]interface{}, object map[string]interface{}, path string) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	required, _ := schema["required"].([]interface{})
+	for _, name := range required {
+		if _, present := object[name.(string)]; !present {
+			checker.addError(joinSchemaPath(path, name.(string)), "required")
+		}
+	}
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		keyPath := joinSchemaPath(path, key)
+		if property, known := properties[key].(map[string]interface{}); known {
+			checker.check(property, object[key], keyPath)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				checker.addError(keyPath, "unknown key")
+			}
+		case map[string]interface{}:
+			checker.check(additional, object[key], keyPath)
+		}
+	}
+}
+
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return "unknown"
+}
+
+func matchesType(allowed interface{}, value interface{}) bool {
+	actual := jsonType(value)
+	types, ok := allowed.([]interface{})
+	if !ok {
+		types = []interface{}{allowed}
+	}
+	for _, candidate := range types {
+		if candidate == actual || (candidate == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if fmt.Sprint(candidate) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checkAgainstSpecSchema(t *testing.T, document string) []string {
+	errors, err := CheckDocument(generateContextSchema(t), []byte(document))
+	require.NoError(t, err)
+	return errors
+}
+
+func TestCheckDocument_ValidDocument(t *testing.T) {
+	document := `name: "Valid"
+scenarios:
+  - id: group
+    on_failure: continue
+    env:
+      PORT: 8080
+    before:
+      run: echo setup
+      timeout: 5s
+    scenarios:
+      - id: leaf
+        retries: 2
+`
+	assert.Empty(t, checkAgainstSpecSchema(t, document))
+}
+
+func TestCheckDocument_ReportsUnknownKeys(t *testing.T) {
+	errors := checkAgainstSpecSchema(t, "name: x\nbefor_each:\n  run: echo\n")
+
+	assert.Equal(t, []string{"befor_each: unknown key"}, errors)
+}
+
+func TestCheckDocument_ReportsMissingRequiredField(t *testing.T) {
+	errors := checkAgainstSpecSchema(t, "scenarios:\n  - scenarios:\n      - id: leaf\n")
+
+	assert.Equal(t, []string{"scenarios[0].id: required"}, errors)
+}
+
+func TestCheckDocument_ReportsEnumAndPattern(t *testing.T) {
+	document := `scenarios:
+  - id: leaf
+    on_failure: explode
+    before:
+      run: echo
+      timeout: forever
+`
+	errors := checkAgainstSpecSchema(t, document)
+
+	require.Len(t, errors, 2)
+	assert.Contains(t, errors[0], "scenarios[0].before.timeout: does not match")
+	assert.Contains(t, errors[1], "scenarios[0].on_failure: must be one of")
+}
+
+func TestCheckDocument_ReportsWrongType(t *testing.T) {
+	errors := checkAgainstSpecSchema(t, "scenarios:\n  - id: leaf\n    parallel: sometimes\n")
+
+	assert.Equal(t, []string{"scenarios[0].parallel: expected boolean, got string"}, errors)
+}
+
+func TestCheckDocument_InvalidYaml(t *testing.T) {
+	_, err := CheckDocument(generateContextSchema(t), []byte("name: [unclosed"))
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+
+	"basanos/internal/spec"
+)
+
+const durationPattern = `^(0|([0-9]+(\.[0-9]*)?(ns|us|µs|ms|s|m|h))+)$`
+
+var specFieldConstraints = map[string]map[string]interface{}{
+	"on_failure":  {"enum": []string{"skip_children", "continue", "abort_run"}},
+	"timeout":     {"pattern": durationPattern},
+	"retry_delay": {"pattern": durationPattern},
+	"interval":    {"pattern": durationPattern},
+	"within":      {"pattern": durationPattern},
+	"retries":     {"minimum": 0},
+	"preset":      {"enum": spec.NormalizePresetNames()},
+}
+
+var specRequiredFields = map[string][]string{
+	"Scenario": {"id"},
+	"Hook":     {"run"},
+	"RunBlock": {"command"},
+	"Service":  {"name", "run"},
+}
+
+func GenerateContextSchema(source string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", source, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	structs := findStructTypesFromAST(file)
+	defs := make(map[string]interface{})
+	for name, structType := range structs {
+		defs[name] = buildSpecTypeDef(name, structType, structs)
+	}
+
+	schema := map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "basanos context.yaml",
+		"$ref":    "#/$defs/Context",
+		"$defs":   defs,
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func findStructTypesFromAST(file *ast.File) map[string]*ast.StructType {
+	structs := make(map[string]*ast.StructType)
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range genDecl.Specs {
+			typeSpec := s.(*ast.TypeSpec)
+			if structType, isStruct := typeSpec.Type.(*ast.StructType); isStruct {
+				structs[typeSpec.Name.Name] = structType
+			}
+		}
+	}
+	return structs
+}
+
+func buildSpecTypeDef(name string, structType *ast.StructType, structs map[string]*ast.StructType) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for _, field := range structType.Fields.List {
+		if len(field.Names) == 0 {
+			continue
+		}
+		fieldName := yamlFieldName(field.Tag, field.Names[0].Name)
+		if fieldName == "-" {
+			continue
+		}
+		property := specTypeSchema(field.Type, structs)
+		for key, value := range specFieldConstraints[fieldName] {
+			property[key] = value
+		}
+		properties[fieldName] = property
+	}
+	required := specRequiredFields[name]
+	if required == nil {
+		required = []string{}
+	}
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             required,
+		"additionalProperties": false,
+	}
+}
+
+func yamlFieldName(tag *ast.BasicLit, goName string) string {
+	if tag == nil {
+		return strings.ToLower(goName)
+	}
+	value, _ := reflect.StructTag(strings.Trim(tag.Value, "`")).Lookup("yaml")
+	name, _, _ := strings.Cut(value, ",")
+	if name == "" {
+		return strings.ToLower(goName)
+	}
+	return name
+}
+
+func specTypeSchema(expr ast.Expr, structs map[string]*ast.StructType) map[string]interface{} {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return specTypeSchema(t.X, structs)
+	case *ast.ArrayType:
+		return map[string]interface{}{"type": "array", "items": specTypeSchema(t.Elt, structs)}
+	case *ast.MapType:
+		return map[string]interface{}{"type": "object", "additionalProperties": scalarValueSchema()}
+	case *ast.Ident:
+		if _, isStruct := structs[t.Name]; isStruct {
+			return map[string]interface{}{"$ref": "#/$defs/" + t.Name}
+		}
+		switch t.Name {
+		case "int":
+			return map[string]interface{}{"type": "integer"}
+		case "bool":
+			return map[string]interface{}{"type": "boolean"}
+		}
+	}
+	return map[string]interface{}{"type": "string"}
+}
+
+func scalarValueSchema() map[string]interface{} {
+	return map[string]interface{}{"type": []string{"string", "number", "boolean"}}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"basanos/internal/spec"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const specSource = `package spec
+
+type Hook struct {
+	Run     string ` + "`yaml:\"run\"`" + `
+	Timeout string ` + "`yaml:\"timeout\"`" + `
+}
+
+type NormalizeRule struct {
+	Preset string ` + "`yaml:\"preset\"`" + `
+}
+
+type Scenario struct {
+	ID        string            ` + "`yaml:\"id\"`" + `
+	OnFailure string            ` + "`yaml:\"on_failure\"`" + `
+	Retries   int               ` + "`yaml:\"retries\"`" + `
+	Parallel  bool              ` + "`yaml:\"parallel\"`" + `
+	Env       map[string]string ` + "`yaml:\"env\"`" + `
+	Before    *Hook             ` + "`yaml:\"before\"`" + `
+	Scenarios []Scenario        ` + "`yaml:\"scenarios\"`" + `
+}
+
+type Context struct {
+	Name      string     ` + "`yaml:\"name\"`" + `
+	Scenarios []Scenario ` + "`yaml:\"scenarios\"`" + `
+}`
+
+func generateContextSchema(t *testing.T) map[string]interface{} {
+	result, err := GenerateContextSchema(specSource)
+	require.NoError(t, err)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(result, &schema))
+	return schema
+}
+
+func specDef(schema map[string]interface{}, name string) map[string]interface{} {
+	return schema["$defs"].(map[string]interface{})[name].(map[string]interface{})
+}
+
+func specProperty(schema map[string]interface{}, typeName, property string) map[string]interface{} {
+	return specDef(schema, typeName)["properties"].(map[string]interface{})[property].(map[string]interface{})
+}
+
+func TestGenerateContextSchema_RootReferencesContext(t *testing.T) {
+	schema := generateContextSchema(t)
+
+	assert.Equal(t, "https://json-schema.org/draft/2020-12/schema", schema["$schema"])
+	assert.Equal(t, "#/$defs/Context", schema["$ref"])
+	assert.Equal(t, false, specDef(schema, "Context")["additionalProperties"])
+}
+
+func TestGenerateContextSchema_UsesYamlTagsAndTypes(t *testing.T) {
+	schema := generateContextSchema(t)
+
+	assert.Equal(t, "string", specProperty(schema, "Scenario", "id")["type"])
+	assert.Equal(t, "integer", specProperty(schema, "Scenario", "retries")["type"])
+	assert.Equal(t, "boolean", specProperty(schema, "Scenario", "parallel")["type"])
+	assert.Equal(t, "object", specProperty(schema, "Scenario", "env")["type"])
+	assert.Equal(t, "#/$defs/Hook", specProperty(schema, "Scenario", "before")["$ref"])
+}
+
+func TestGenerateContextSchema_ScenariosAreRecursive(t *testing.T) {
+	schema := generateContextSchema(t)
+
+	nested := specProperty(schema, "Scenario", "scenarios")
+	assert.Equal(t, "array", nested["type"])
+	assert.Equal(t, "#/$defs/Scenario", nested["items"].(map[string]interface{})["$ref"])
+}
+
+func TestGenerateContextSchema_ConstrainsOnFailureAndTimeout(t *testing.T) {
+	schema := generateContextSchema(t)
+
+	assert.Equal(t, []interface{}{"skip_children", "continue", "abort_run"}, specProperty(schema, "Scenario", "on_failure")["enum"])
+	assert.Equal(t, durationPattern, specProperty(schema, "Hook", "timeout")["pattern"])
+}
+
+func TestGenerateContextSchema_PresetEnumComesFromSpec(t *testing.T) {
+	schema := generateContextSchema(t)
+
+	var expected []interface{}
+	for _, name := range spec.NormalizePresetNames() {
+		expected = append(expected, name)
+	}
+	assert.Equal(t, expected, specProperty(schema, "NormalizeRule", "preset")["enum"])
+}
+
+func TestGenerateContextSchema_RequiredFields(t *testing.T) {
+	schema := generateContextSchema(t)
+
+	assert.Equal(t, []interface{}{"id"}, specDef(schema, "Scenario")["required"])
+	assert.Equal(t, []interface{}{"run"}, specDef(schema, "Hook")["required"])
+	assert.Equal(t, []interface{}{}, specDef(schema, "Context")["required"])
+}
@@ -1,18 +1,40 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 )
 
 func main() {
-	source, err := os.ReadFile("internal/event/event.go")
+	args := os.Args[1:]
+	if len(args) == 0 {
+		printSchema("internal/event/event.go", GenerateSchema)
+		return
+	}
+	switch args[0] {
+	case "context":
+		printSchema("internal/spec/context.go", GenerateContextSchema)
+	case "check":
+		if len(args) < 3 {
+			fmt.Fprintln(os.Stderr, "Usage: gen-schema check <schema.json> <context.yaml>...")
+			os.Exit(2)
+		}
+		os.Exit(checkFiles(args[1], args[2:]))
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", args[0])
+		os.Exit(2)
+	}
+}
+
+func printSchema(sourcePath string, generate func(string) ([]byte, error)) {
+	source, err := os.ReadFile(sourcePath)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading event.go: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", sourcePath, err)
 		os.Exit(1)
 	}
 
-	schema, err := GenerateSchema(string(source))
+	schema, err := generate(string(source))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating schema: %v\n", err)
 		os.Exit(1)
@@ -20,3 +42,41 @@ func main() {
 
 	fmt.Println(string(schema))
 }
+
+func checkFiles(schemaPath string, files []string) int {
+	data, err := os.ReadFile(schemaPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", schemaPath, err)
+		return 1
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing %s: %v\n", schemaPath, err)
+		return 1
+	}
+
+	failed := 0
+	for _, file := range files {
+		document, err := os.ReadFile(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", file, err)
+			return 1
+		}
+		errors, err := CheckDocument(schema, document)
+		if err != nil {
+			errors = []string{err.Error()}
+		}
+		for _, message := range errors {
+			fmt.Printf("%s: %s\n", file, message)
+		}
+		if len(errors) > 0 {
+			failed++
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("%d of %d files invalid\n", failed, len(files))
+		return 1
+	}
+	fmt.Printf("%d files valid\n", len(files))
+	return 0
+}
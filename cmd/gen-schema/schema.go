@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"strconv"
 	"strings"
 )
 
@@ -48,6 +49,7 @@ func isEventTypeName(name string) bool {
 type FieldInfo struct {
 	Name     string
 	Type     string
+	Items    string
 	Required bool
 }
 
@@ -94,6 +96,7 @@ func extractFieldsFromStruct(file *ast.File, structType *ast.StructType) []Field
 		fields = append(fields, FieldInfo{
 			Name:     fieldName(tagName, field.Names[0].Name),
 			Type:     mapGoTypeToJsonSchema(field.Type),
+			Items:    arrayItemType(field.Type),
 			Required: required,
 		})
 	}
@@ -135,17 +138,29 @@ func mapGoTypeToJsonSchema(expr ast.Expr) string {
 		switch t.Name {
 		case "string":
 			return "string"
-		case "int":
+		case "int", "int64":
 			return "integer"
+		case "bool":
+			return "boolean"
 		}
 	case *ast.SelectorExpr:
 		if isTimeType(t) {
 			return "string"
 		}
+	case *ast.ArrayType:
+		return "array"
 	}
 	return "string"
 }
 
+func arrayItemType(expr ast.Expr) string {
+	arrayType, ok := expr.(*ast.ArrayType)
+	if !ok {
+		return ""
+	}
+	return mapGoTypeToJsonSchema(arrayType.Elt)
+}
+
 func isTimeType(expr *ast.SelectorExpr) bool {
 	ident, ok := expr.X.(*ast.Ident)
 	if !ok {
@@ -162,13 +177,18 @@ func GenerateSchema(source string) ([]byte, error) {
 	}
 
 	eventTypes := findEventTypesFromAST(file)
+	eventNames := findEventNamesFromAST(file)
 
 	defs := make(map[string]interface{})
 	oneOf := make([]interface{}, 0, len(eventTypes))
 
 	for _, typeName := range eventTypes {
 		fields := extractFieldsFromAST(file, typeName)
-		defs[typeName] = buildTypeDef(fields)
+		typeDef := buildTypeDef(fields)
+		if name, ok := eventNames[typeName]; ok {
+			addEventConst(typeDef, name)
+		}
+		defs[typeName] = typeDef
 		oneOf = append(oneOf, map[string]string{"$ref": "#/$defs/" + typeName})
 	}
 
@@ -181,6 +201,85 @@ func GenerateSchema(source string) ([]byte, error) {
 	return json.MarshalIndent(schema, "", "  ")
 }
 
+func FindEventNames(source string) map[string]string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", source, 0)
+	if err != nil {
+		return nil
+	}
+	return findEventNamesFromAST(file)
+}
+
+func findEventNamesFromAST(file *ast.File) map[string]string {
+	names := make(map[string]string)
+	ast.Inspect(file, func(node ast.Node) bool {
+		literal, ok := node.(*ast.CompositeLit)
+		if !ok {
+			return true
+		}
+		typeIdent, ok := literal.Type.(*ast.Ident)
+		if !ok || !isEventTypeName(typeIdent.Name) {
+			return true
+		}
+		if name, found := baseEventName(literal); found {
+			names[typeIdent.Name] = name
+		}
+		return true
+	})
+	return names
+}
+
+func baseEventName(literal *ast.CompositeLit) (string, bool) {
+	for _, element := range literal.Elts {
+		keyValue, ok := element.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := keyValue.Key.(*ast.Ident)
+		if !ok || key.Name != "BaseEvent" {
+			continue
+		}
+		base, ok := keyValue.Value.(*ast.CompositeLit)
+		if !ok {
+			continue
+		}
+		return eventFieldValue(base)
+	}
+	return "", false
+}
+
+func eventFieldValue(base *ast.CompositeLit) (string, bool) {
+	for _, element := range base.Elts {
+		keyValue, ok := element.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		key, ok := keyValue.Key.(*ast.Ident)
+		if !ok || key.Name != "Event" {
+			continue
+		}
+		value, ok := keyValue.Value.(*ast.BasicLit)
+		if !ok || value.Kind != token.STRING {
+			continue
+		}
+		name, err := strconv.Unquote(value.Value)
+		if err != nil {
+			continue
+		}
+		return name, true
+	}
+	return "", false
+}
+
+func addEventConst(typeDef map[string]interface{}, name string) {
+	properties := typeDef["properties"].(map[string]interface{})
+	property, ok := properties["event"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	property["const"] = name
+}
+
 func buildTypeDef(fields []FieldInfo) map[string]interface{} {
 	return map[string]interface{}{
 		"type":                 "object",
@@ -193,7 +292,11 @@ func buildTypeDef(fields []FieldInfo) map[string]interface{} {
 func buildProperties(fields []FieldInfo) map[string]interface{} {
 	properties := make(map[string]interface{})
 	for _, field := range fields {
-		properties[field.Name] = map[string]string{"type": field.Type}
+		property := map[string]interface{}{"type": field.Type}
+		if field.Items != "" {
+			property["items"] = map[string]string{"type": field.Items}
+		}
+		properties[field.Name] = property
 	}
 	return properties
 }
@@ -90,6 +90,39 @@ type FooEvent struct {
 	assert.Equal(t, expected, result)
 }
 
+func TestExtractFields_SliceMapsToArrayWithItems(t *testing.T) {
+	source := `package event
+
+type FooEvent struct {
+	IDs []int ` + "`json:\"ids\"`" + `
+}`
+
+	result := ExtractFields(source, "FooEvent")
+
+	expected := []FieldInfo{
+		{Name: "ids", Type: "array", Items: "integer", Required: true},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestGenerateSchema_ArrayFieldHasItems(t *testing.T) {
+	source := `package event
+
+type FooEvent struct {
+	IDs []int ` + "`json:\"ids\"`" + `
+}`
+
+	result, err := GenerateSchema(source)
+
+	assert.NoError(t, err)
+	var schema map[string]interface{}
+	assert.NoError(t, json.Unmarshal(result, &schema))
+	fooEvent := schema["$defs"].(map[string]interface{})["FooEvent"].(map[string]interface{})
+	ids := fooEvent["properties"].(map[string]interface{})["ids"].(map[string]interface{})
+	assert.Equal(t, "array", ids["type"])
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, ids["items"])
+}
+
 func TestExtractFields_OmitemptyMarksFieldNotRequired(t *testing.T) {
 	source := `package event
 
@@ -248,3 +281,77 @@ type FooEvent struct {
 	}
 	assert.Equal(t, expected, result)
 }
+
+func TestExtractFields_Int64AndBoolTypes(t *testing.T) {
+	source := `package event
+
+type FooEvent struct {
+	Seq  int64 ` + "`json:\"seq\"`" + `
+	Done bool  ` + "`json:\"done\"`" + `
+}`
+
+	result := ExtractFields(source, "FooEvent")
+
+	expected := []FieldInfo{
+		{Name: "seq", Type: "integer", Required: true},
+		{Name: "done", Type: "boolean", Required: true},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestFindEventNames_ReadsNameFromConstructor(t *testing.T) {
+	source := `package event
+
+type BaseEvent struct {
+	Event string ` + "`json:\"event\"`" + `
+}
+
+type FooEvent struct {
+	BaseEvent
+}
+
+type BarEvent struct {
+	BaseEvent
+}
+
+func NewFooEvent() *FooEvent {
+	return &FooEvent{BaseEvent: BaseEvent{Event: "foo"}}
+}
+
+func NewBarEvent() *BarEvent {
+	return &BarEvent{
+		BaseEvent: BaseEvent{Event: "bar_happened"},
+	}
+}`
+
+	result := FindEventNames(source)
+
+	assert.Equal(t, map[string]string{"FooEvent": "foo", "BarEvent": "bar_happened"}, result)
+}
+
+func TestGenerateSchema_EventPropertyHasConstDiscriminator(t *testing.T) {
+	source := `package event
+
+type BaseEvent struct {
+	Event string ` + "`json:\"event\"`" + `
+}
+
+type FooEvent struct {
+	BaseEvent
+	Name string ` + "`json:\"name\"`" + `
+}
+
+func NewFooEvent(name string) *FooEvent {
+	return &FooEvent{BaseEvent: BaseEvent{Event: "foo"}, Name: name}
+}`
+
+	result, err := GenerateSchema(source)
+
+	assert.NoError(t, err)
+	var schema map[string]interface{}
+	assert.NoError(t, json.Unmarshal(result, &schema))
+	defs := schema["$defs"].(map[string]interface{})
+	properties := defs["FooEvent"].(map[string]interface{})["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string", "const": "foo"}, properties["event"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, properties["name"])
+}
@@ -5,11 +5,20 @@ import (
 	"io"
 )
 
+const (
+	FailedExitCode = 1
+	ErrorExitCode  = 2
+)
+
 type AssertResult interface {
 	Format() string
 	IsPassed() bool
 }
 
+type erroredResult interface {
+	IsErrored() bool
+}
+
 type BaseResult struct {
 	Passed bool
 }
@@ -34,16 +43,19 @@ func RunCLI(args []string, stdin io.Reader, stdout io.Writer,
 
 	if err != nil {
 		fmt.Fprintln(stdout, err.Error())
-		return 1
+		return ErrorExitCode
 	}
 
 	result := assertFn(first, second)
 	fmt.Fprint(stdout, result.Format())
 
+	if errored, ok := result.(erroredResult); ok && errored.IsErrored() {
+		return ErrorExitCode
+	}
 	if result.IsPassed() {
 		return 0
 	}
-	return 1
+	return FailedExitCode
 }
 
 func requireTwoArgs(args []string) error {
@@ -48,6 +48,32 @@ func TestRunCLI_StdinMode_FailingAssertion(t *testing.T) {
 	assert.Equal(t, 1, exitCode)
 }
 
+func TestRunCLI_ResolveError_ExitsWithErrorCode(t *testing.T) {
+	stdout := &bytes.Buffer{}
+
+	exitCode := RunCLI([]string{"only-one"}, nil, stdout, ResolveLiterals, passingAssert)
+
+	assert.Equal(t, ErrorExitCode, exitCode)
+	assert.Equal(t, "expected 2 arguments, got 1\n", stdout.String())
+}
+
+func TestRunCLI_ErroredResult_ExitsWithErrorCode(t *testing.T) {
+	stdout := &bytes.Buffer{}
+
+	exitCode := RunCLI([]string{"$.items[x]", "{}"}, nil, stdout, ResolveLiterals, JSONPath)
+
+	assert.Equal(t, ErrorExitCode, exitCode)
+	assert.Contains(t, stdout.String(), "FAIL: invalid JSONPath query")
+}
+
+func TestRunCLI_MissingJSONPathValue_ExitsWithFailedCode(t *testing.T) {
+	stdout := &bytes.Buffer{}
+
+	exitCode := RunCLI([]string{"$.user", "{}"}, nil, stdout, ResolveLiterals, JSONPath)
+
+	assert.Equal(t, FailedExitCode, exitCode)
+}
+
 func TestRunCLI_ArgsMode_UsesResolver(t *testing.T) {
 	var resolvedFirst, resolvedSecond string
 	trackingResolver := func(args []string) (string, string, error) {
@@ -0,0 +1,211 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type JSONDifference struct {
+	Pointer  string
+	Expected string
+	Actual   string
+}
+
+type JSONEqualsResult struct {
+	BaseResult
+	Differences []JSONDifference
+	Error       string
+}
+
+func JSONEquals(expected, actual string) AssertResult {
+	result := &JSONEqualsResult{}
+
+	expectedValue, err := parseJSON(expected)
+	if err != nil {
+		result.Error = "expected is not valid JSON: " + err.Error()
+		return result
+	}
+	actualValue, err := parseJSON(actual)
+	if err != nil {
+		result.Error = "actual is not valid JSON: " + err.Error()
+		return result
+	}
+
+	result.Differences = diffJSON("", expectedValue, actualValue, nil)
+	result.Passed = len(result.Differences) == 0
+	return result
+}
+
+func parseJSON(text string) (any, error) {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected content after the JSON value")
+	}
+	return value, nil
+}
+
+func jsonValuesEqual(expected, actual any) bool {
+	switch typed := expected.(type) {
+	case json.Number:
+		other, isNumber := actual.(json.Number)
+		return isNumber && jsonNumbersEqual(typed, other)
+	case map[string]any:
+		other, isObject := actual.(map[string]any)
+		if !isObject || len(typed) != len(other) {
+			return false
+		}
+		for key, value := range typed {
+			otherValue, exists := other[key]
+			if !exists || !jsonValuesEqual(value, otherValue) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		other, isArray := actual.([]any)
+		if !isArray || len(typed) != len(other) {
+			return false
+		}
+		for index, value := range typed {
+			if !jsonValuesEqual(value, other[index]) {
+				return false
+			}
+		}
+		return true
+	}
+	return expected == actual
+}
+
+func jsonNumbersEqual(expected, actual json.Number) bool {
+	expectedValue, expectedOK := new(big.Rat).SetString(string(expected))
+	actualValue, actualOK := new(big.Rat).SetString(string(actual))
+	if !expectedOK || !actualOK {
+		return expected == actual
+	}
+	return expectedValue.Cmp(actualValue) == 0
+}
+
+func diffJSON(pointer string, expected, actual any, differences []JSONDifference) []JSONDifference {
+	expectedObject, expectedIsObject := expected.(map[string]any)
+	actualObject, actualIsObject := actual.(map[string]any)
+	if expectedIsObject && actualIsObject {
+		return diffJSONObjects(pointer, expectedObject, actualObject, differences)
+	}
+
+	expectedArray, expectedIsArray := expected.([]any)
+	actualArray, actualIsArray := actual.([]any)
+	if expectedIsArray && actualIsArray {
+		return diffJSONArrays(pointer, expectedArray, actualArray, differences)
+	}
+
+	if jsonValuesEqual(expected, actual) {
+		return differences
+	}
+	return append(differences, JSONDifference{
+		Pointer:  pointer,
+		Expected: formatJSON(expected),
+		Actual:   formatJSON(actual),
+	})
+}
+
+func diffJSONObjects(pointer string, expected, actual map[string]any, differences []JSONDifference) []JSONDifference {
+	keys := make(map[string]bool)
+	for key := range expected {
+		keys[key] = true
+	}
+	for key := range actual {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		child := pointer + "/" + escapePointerToken(key)
+		expectedValue, inExpected := expected[key]
+		actualValue, inActual := actual[key]
+		switch {
+		case !inActual:
+			differences = append(differences, JSONDifference{Pointer: child, Expected: formatJSON(expectedValue)})
+		case !inExpected:
+			differences = append(differences, JSONDifference{Pointer: child, Actual: formatJSON(actualValue)})
+		default:
+			differences = diffJSON(child, expectedValue, actualValue, differences)
+		}
+	}
+	return differences
+}
+
+func diffJSONArrays(pointer string, expected, actual []any, differences []JSONDifference) []JSONDifference {
+	for index := 0; index < len(expected) || index < len(actual); index++ {
+		child := pointer + "/" + strconv.Itoa(index)
+		switch {
+		case index >= len(actual):
+			differences = append(differences, JSONDifference{Pointer: child, Expected: formatJSON(expected[index])})
+		case index >= len(expected):
+			differences = append(differences, JSONDifference{Pointer: child, Actual: formatJSON(actual[index])})
+		default:
+			differences = diffJSON(child, expected[index], actual[index], differences)
+		}
+	}
+	return differences
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func formatJSON(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+func (difference JSONDifference) format() string {
+	pointer := difference.Pointer
+	if pointer == "" {
+		pointer = "(root)"
+	}
+	switch {
+	case difference.Actual == "":
+		return pointer + ": missing, expected " + difference.Expected
+	case difference.Expected == "":
+		return pointer + ": unexpected " + difference.Actual
+	}
+	return pointer + ": expected " + difference.Expected + ", got " + difference.Actual
+}
+
+func (result *JSONEqualsResult) IsErrored() bool {
+	return result.Error != ""
+}
+
+func (result *JSONEqualsResult) Format() string {
+	if result.Passed {
+		return "PASS: JSON values are equal\n"
+	}
+	if result.Error != "" {
+		return "FAIL: " + result.Error + "\n"
+	}
+
+	var output strings.Builder
+	output.WriteString("FAIL: JSON values differ\n")
+	output.WriteString("──────────────────────────────────\n")
+	output.WriteString("Differences:\n")
+	for _, difference := range result.Differences {
+		output.WriteString("  " + difference.format() + "\n")
+	}
+	return output.String()
+}
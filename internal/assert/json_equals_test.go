@@ -0,0 +1,81 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONEquals_ReorderedKeysAndWhitespace_Pass(t *testing.T) {
+	result := JSONEquals(`{"name":"alice","roles":["admin"]}`, "{\n  \"roles\": [ \"admin\" ],\n  \"name\": \"alice\"\n}\n")
+
+	assert.True(t, result.IsPassed())
+	assert.Equal(t, "PASS: JSON values are equal\n", result.Format())
+}
+
+func TestJSONEquals_EquivalentNumbers_Pass(t *testing.T) {
+	result := JSONEquals(`{"total": 10}`, `{"total": 10.0}`)
+
+	assert.True(t, result.IsPassed())
+}
+
+func TestJSONEquals_LargeIntegersThatDiffer_Fail(t *testing.T) {
+	result := JSONEquals(`{"id": 9007199254740993}`, `{"id": 9007199254740992}`)
+
+	assert.False(t, result.IsPassed())
+	assert.Contains(t, result.Format(), "  /id: expected 9007199254740993, got 9007199254740992")
+}
+
+func TestJSONEquals_ChangedValue_ReportsPointer(t *testing.T) {
+	result := JSONEquals(`{"user":{"name":"alice"}}`, `{"user":{"name":"bob"}}`)
+
+	assert.False(t, result.IsPassed())
+	assert.Equal(t, []JSONDifference{{Pointer: "/user/name", Expected: `"alice"`, Actual: `"bob"`}},
+		result.(*JSONEqualsResult).Differences)
+	assert.Contains(t, result.Format(), `  /user/name: expected "alice", got "bob"`)
+}
+
+func TestJSONEquals_MissingAndUnexpectedKeys_AreReported(t *testing.T) {
+	result := JSONEquals(`{"id":1,"email":"a@example.com"}`, `{"id":1,"phone":"555"}`)
+
+	output := result.Format()
+
+	assert.False(t, result.IsPassed())
+	assert.Contains(t, output, `  /email: missing, expected "a@example.com"`)
+	assert.Contains(t, output, `  /phone: unexpected "555"`)
+}
+
+func TestJSONEquals_ArrayElements_AreComparedByIndex(t *testing.T) {
+	result := JSONEquals(`[1,2,3]`, `[1,5]`)
+
+	output := result.Format()
+
+	assert.Contains(t, output, "  /1: expected 2, got 5")
+	assert.Contains(t, output, "  /2: missing, expected 3")
+}
+
+func TestJSONEquals_EscapesPointerTokens(t *testing.T) {
+	result := JSONEquals(`{"a/b":{"c~d":1}}`, `{"a/b":{"c~d":2}}`)
+
+	assert.Contains(t, result.Format(), "  /a~1b/c~0d: expected 1, got 2")
+}
+
+func TestJSONEquals_DifferentTypesAtRoot_ReportsRoot(t *testing.T) {
+	result := JSONEquals(`[]`, `{}`)
+
+	assert.Contains(t, result.Format(), "  (root): expected [], got {}")
+}
+
+func TestJSONEquals_InvalidJSON_Fails(t *testing.T) {
+	result := JSONEquals(`{"id":1}`, "not json")
+
+	assert.False(t, result.IsPassed())
+	assert.Contains(t, result.Format(), "FAIL: actual is not valid JSON")
+}
+
+func TestJSONEquals_TrailingContent_Fails(t *testing.T) {
+	result := JSONEquals(`{"id":1} {"id":2}`, `{"id":1}`)
+
+	assert.False(t, result.IsPassed())
+	assert.Contains(t, result.Format(), "FAIL: expected is not valid JSON")
+}
@@ -0,0 +1,266 @@
+package assert
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type JSONPathResult struct {
+	BaseResult
+	Path     string
+	Operator string
+	Expected string
+	Matches  []string
+	Missing  bool
+	Error    string
+}
+
+type jsonPathQuery struct {
+	path     string
+	segments []jsonPathSegment
+	operator string
+	operand  string
+}
+
+type jsonPathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func JSONPath(query, actual string) AssertResult {
+	result := &JSONPathResult{}
+
+	parsed, err := parseJSONPathQuery(query)
+	if err != nil {
+		result.Error = "invalid JSONPath query: " + err.Error()
+		return result
+	}
+	result.Path = parsed.path
+	result.Operator = parsed.operator
+	result.Expected = parsed.operand
+
+	document, err := parseJSON(actual)
+	if err != nil {
+		result.Error = "actual is not valid JSON: " + err.Error()
+		return result
+	}
+
+	matches := parsed.evaluate(document)
+	for _, match := range matches {
+		result.Matches = append(result.Matches, formatJSON(match))
+	}
+	if len(matches) == 0 {
+		result.Missing = true
+		result.Error = "no value at " + parsed.path
+		return result
+	}
+
+	switch parsed.operator {
+	case "==":
+		result.Passed = jsonValuesEqual(parseOperand(parsed.operand), parsed.selected(matches))
+	case "=~":
+		regex, err := regexp.Compile(parsed.operand)
+		if err != nil {
+			result.Error = "invalid regex pattern: " + err.Error()
+			return result
+		}
+		result.Passed = allMatch(regex, matches)
+	default:
+		result.Passed = true
+	}
+	return result
+}
+
+func parseOperand(operand string) any {
+	value, err := parseJSON(operand)
+	if err != nil {
+		return operand
+	}
+	return value
+}
+
+func allMatch(regex *regexp.Regexp, values []any) bool {
+	for _, value := range values {
+		text, isString := value.(string)
+		if !isString {
+			text = formatJSON(value)
+		}
+		if !regex.MatchString(text) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseJSONPathQuery(query string) (*jsonPathQuery, error) {
+	query = strings.TrimSpace(query)
+	if !strings.HasPrefix(query, "$") {
+		return nil, fmt.Errorf("path must start with $")
+	}
+
+	parsed := &jsonPathQuery{}
+	position := 1
+	for position < len(query) && query[position] != ' ' {
+		segment, next, err := parseJSONPathSegment(query, position)
+		if err != nil {
+			return nil, err
+		}
+		parsed.segments = append(parsed.segments, segment)
+		position = next
+	}
+	parsed.path = query[:position]
+
+	rest := strings.TrimSpace(query[position:])
+	if rest == "" {
+		return parsed, nil
+	}
+	for _, operator := range []string{"==", "=~"} {
+		if strings.HasPrefix(rest, operator) {
+			parsed.operator = operator
+			parsed.operand = strings.TrimSpace(rest[len(operator):])
+			return parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("expected == or =~ after %s", parsed.path)
+}
+
+func parseJSONPathSegment(query string, position int) (jsonPathSegment, int, error) {
+	switch query[position] {
+	case '.':
+		end := position + 1
+		for end < len(query) && !strings.ContainsRune(".[ ", rune(query[end])) {
+			end++
+		}
+		name := query[position+1 : end]
+		if name == "" {
+			return jsonPathSegment{}, 0, fmt.Errorf("missing name after . at offset %d", position)
+		}
+		return jsonPathSegment{key: name, wildcard: name == "*"}, end, nil
+	case '[':
+		end := strings.IndexByte(query[position:], ']')
+		if end < 0 {
+			return jsonPathSegment{}, 0, fmt.Errorf("unclosed [ at offset %d", position)
+		}
+		segment, err := parseBracket(query[position+1 : position+end])
+		return segment, position + end + 1, err
+	}
+	return jsonPathSegment{}, 0, fmt.Errorf("unexpected %q at offset %d", query[position], position)
+}
+
+func parseBracket(content string) (jsonPathSegment, error) {
+	if content == "*" {
+		return jsonPathSegment{wildcard: true}, nil
+	}
+	if len(content) >= 2 && (content[0] == '\'' || content[0] == '"') && content[len(content)-1] == content[0] {
+		return jsonPathSegment{key: content[1 : len(content)-1]}, nil
+	}
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return jsonPathSegment{}, fmt.Errorf("invalid index [%s]", content)
+	}
+	return jsonPathSegment{index: index, isIndex: true}, nil
+}
+
+func (query *jsonPathQuery) evaluate(document any) []any {
+	nodes := []any{document}
+	for _, segment := range query.segments {
+		var next []any
+		for _, node := range nodes {
+			next = append(next, segment.apply(node)...)
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func (query *jsonPathQuery) selected(matches []any) any {
+	for _, segment := range query.segments {
+		if segment.wildcard {
+			return matches
+		}
+	}
+	return matches[0]
+}
+
+func (segment jsonPathSegment) apply(node any) []any {
+	switch typed := node.(type) {
+	case map[string]any:
+		if segment.wildcard {
+			return objectValues(typed)
+		}
+		if value, ok := typed[segment.key]; ok && !segment.isIndex {
+			return []any{value}
+		}
+	case []any:
+		if segment.wildcard {
+			return typed
+		}
+		index := segment.index
+		if index < 0 {
+			index += len(typed)
+		}
+		if segment.isIndex && index >= 0 && index < len(typed) {
+			return []any{typed[index]}
+		}
+	}
+	return nil
+}
+
+func objectValues(object map[string]any) []any {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	values := make([]any, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, object[key])
+	}
+	return values
+}
+
+func (result *JSONPathResult) IsErrored() bool {
+	return result.Error != "" && !result.Missing
+}
+
+func (result *JSONPathResult) Format() string {
+	if result.Error != "" {
+		return "FAIL: " + result.Error + "\n"
+	}
+	if result.Passed {
+		return result.passMessage()
+	}
+
+	var output strings.Builder
+	output.WriteString("FAIL: " + result.Path + " " + result.failureVerb() + "\n")
+	output.WriteString("──────────────────────────────────\n")
+	output.WriteString("Expected:\n")
+	output.WriteString("  " + result.Expected + "\n")
+	output.WriteString("\nActual:\n")
+	for _, match := range result.Matches {
+		output.WriteString("  " + match + "\n")
+	}
+	return output.String()
+}
+
+func (result *JSONPathResult) passMessage() string {
+	switch result.Operator {
+	case "==":
+		return "PASS: " + result.Path + " equals " + result.Expected + "\n"
+	case "=~":
+		return "PASS: " + result.Path + " matches " + result.Expected + "\n"
+	}
+	return "PASS: " + result.Path + " exists\n"
+}
+
+func (result *JSONPathResult) failureVerb() string {
+	if result.Operator == "=~" {
+		return "does not match pattern"
+	}
+	return "differs"
+}
@@ -0,0 +1,99 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const jsonPathDocument = `{
+  "user": {"name": "alice", "id": 42, "first name": "Alice"},
+  "items": [{"sku": "a-1", "qty": 2}, {"sku": "b-2", "qty": 1}],
+  "active": true
+}`
+
+func TestJSONPath_EqualString_Passes(t *testing.T) {
+	result := JSONPath(`$.user.name == "alice"`, jsonPathDocument)
+
+	assert.True(t, result.IsPassed())
+	assert.Equal(t, "PASS: $.user.name equals \"alice\"\n", result.Format())
+}
+
+func TestJSONPath_BareOperand_IsComparedAsString(t *testing.T) {
+	result := JSONPath(`$.user.name == alice`, jsonPathDocument)
+
+	assert.True(t, result.IsPassed())
+}
+
+func TestJSONPath_JSONOperand_IsComparedSemantically(t *testing.T) {
+	assert.True(t, JSONPath(`$.user.id == 42.0`, jsonPathDocument).IsPassed())
+	assert.True(t, JSONPath(`$.active == true`, jsonPathDocument).IsPassed())
+	assert.True(t, JSONPath(`$.items[0] == {"qty": 2, "sku": "a-1"}`, jsonPathDocument).IsPassed())
+}
+
+func TestJSONPath_DifferentValue_Fails(t *testing.T) {
+	result := JSONPath(`$.items[-1].sku == "a-1"`, jsonPathDocument)
+
+	output := result.Format()
+
+	assert.False(t, result.IsPassed())
+	assert.Contains(t, output, "FAIL: $.items[-1].sku differs")
+	assert.Contains(t, output, "Expected:\n  \"a-1\"")
+	assert.Contains(t, output, "Actual:\n  \"b-2\"")
+}
+
+func TestJSONPath_Regex_MatchesStringsAndScalars(t *testing.T) {
+	assert.True(t, JSONPath(`$.user.name =~ ^al`, jsonPathDocument).IsPassed())
+	assert.True(t, JSONPath(`$.user.id =~ ^[0-9]+$`, jsonPathDocument).IsPassed())
+
+	result := JSONPath(`$.user.name =~ ^bo`, jsonPathDocument)
+	assert.False(t, result.IsPassed())
+	assert.Contains(t, result.Format(), "FAIL: $.user.name does not match pattern")
+}
+
+func TestJSONPath_Wildcard_ComparesAllMatches(t *testing.T) {
+	assert.True(t, JSONPath(`$.items[*].sku == ["a-1", "b-2"]`, jsonPathDocument).IsPassed())
+	assert.True(t, JSONPath(`$.items.*.sku =~ ^[a-z]-[0-9]$`, jsonPathDocument).IsPassed())
+}
+
+func TestJSONPath_QuotedBracketKey(t *testing.T) {
+	result := JSONPath(`$.user['first name'] == Alice`, jsonPathDocument)
+
+	assert.True(t, result.IsPassed())
+}
+
+func TestJSONPath_ComparesLargeIntegersExactly(t *testing.T) {
+	document := `{"id": 9007199254740993}`
+
+	assert.True(t, JSONPath(`$.id == 9007199254740993`, document).IsPassed())
+	assert.False(t, JSONPath(`$.id == 9007199254740992`, document).IsPassed())
+}
+
+func TestJSONPath_PathOnly_PassesWhenValueExists(t *testing.T) {
+	assert.Equal(t, "PASS: $.user.id exists\n", JSONPath(`$.user.id`, jsonPathDocument).Format())
+}
+
+func TestJSONPath_MissingValue_Fails(t *testing.T) {
+	result := JSONPath(`$.user.email == "a@example.com"`, jsonPathDocument)
+
+	assert.False(t, result.IsPassed())
+	assert.False(t, result.(*JSONPathResult).IsErrored())
+	assert.Equal(t, "FAIL: no value at $.user.email\n", result.Format())
+}
+
+func TestJSONPath_InvalidQuery_Fails(t *testing.T) {
+	assert.Contains(t, JSONPath(`user.name`, jsonPathDocument).Format(), "FAIL: invalid JSONPath query: path must start with $")
+	assert.Contains(t, JSONPath(`$.items[x]`, jsonPathDocument).Format(), "invalid index [x]")
+	assert.Contains(t, JSONPath(`$.user.name != alice`, jsonPathDocument).Format(), "expected == or =~ after $.user.name")
+}
+
+func TestJSONPath_InvalidQuery_IsErrored(t *testing.T) {
+	assert.True(t, JSONPath(`$.items[x]`, jsonPathDocument).(*JSONPathResult).IsErrored())
+}
+
+func TestJSONPath_InvalidDocument_Fails(t *testing.T) {
+	result := JSONPath(`$.user`, "<html>")
+
+	assert.True(t, result.(*JSONPathResult).IsErrored())
+	assert.Contains(t, result.Format(), "FAIL: actual is not valid JSON")
+}
@@ -28,6 +28,10 @@ func Matches(pattern, target string) AssertResult {
 	return result
 }
 
+func (result *MatchesResult) IsErrored() bool {
+	return result.Error != ""
+}
+
 func (result *MatchesResult) Format() string {
 	if result.Passed {
 		return "PASS: pattern matches target\n"
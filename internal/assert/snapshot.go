@@ -0,0 +1,86 @@
+package assert
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type SnapshotResult struct {
+	BaseResult
+	Path     string
+	Expected string
+	Actual   string
+	Diff     string
+	Status   string
+	Error    string
+}
+
+func Snapshot(update bool) AssertFunc {
+	return func(path, actual string) AssertResult {
+		result := &SnapshotResult{Path: path, Actual: actual}
+		content, err := os.ReadFile(path)
+		existed := err == nil
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			result.Error = err.Error()
+			return result
+		}
+		result.Expected = string(content)
+		if existed && result.Expected == actual {
+			result.Passed = true
+			result.Status = "matched"
+			return result
+		}
+		if existed && !update {
+			result.Status = "stale"
+			result.Diff = generateDiff(result.Expected, actual)
+			return result
+		}
+		if err := writeSnapshot(path, actual); err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		result.Passed = true
+		result.Status = "new"
+		if existed {
+			result.Status = "updated"
+		}
+		return result
+	}
+}
+
+func writeSnapshot(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
+
+func (result *SnapshotResult) IsErrored() bool {
+	return result.Error != ""
+}
+
+func (result *SnapshotResult) Format() string {
+	switch {
+	case result.Error != "":
+		return "FAIL: cannot use snapshot " + result.Path + ": " + result.Error + "\n"
+	case result.Status == "new":
+		return "PASS: snapshot written to " + result.Path + "\n"
+	case result.Status == "updated":
+		return "PASS: snapshot updated at " + result.Path + "\n"
+	case result.Passed:
+		return "PASS: snapshot matches " + result.Path + "\n"
+	}
+
+	var output strings.Builder
+	output.WriteString("FAIL: snapshot is stale\n")
+	output.WriteString("──────────────────────────────────\n")
+	output.WriteString("Snapshot:\n")
+	output.WriteString("  " + result.Path + "\n")
+	output.WriteString("\nDiff:\n")
+	output.WriteString("  " + strings.ReplaceAll(result.Diff, "\n", "\n  ") + "\n")
+	output.WriteString("\nRun with --update-snapshots to accept the new output.\n")
+	return output.String()
+}
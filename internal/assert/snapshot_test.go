@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot_MissingSnapshot_WritesItAndPasses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "__snapshots__", "api", "login.snap")
+
+	result := Snapshot(false)(path, "welcome\n")
+
+	assert.True(t, result.IsPassed())
+	assert.Equal(t, "new", result.(*SnapshotResult).Status)
+	assert.Equal(t, "PASS: snapshot written to "+path+"\n", result.Format())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "welcome\n", string(content))
+}
+
+func TestSnapshot_MatchingSnapshot_Passes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "login.snap")
+	require.NoError(t, os.WriteFile(path, []byte("welcome\n"), 0644))
+
+	result := Snapshot(false)(path, "welcome\n")
+
+	assert.True(t, result.IsPassed())
+	assert.Equal(t, "matched", result.(*SnapshotResult).Status)
+	assert.Equal(t, "PASS: snapshot matches "+path+"\n", result.Format())
+}
+
+func TestSnapshot_DifferentOutput_FailsWithDiffAndKeepsSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "login.snap")
+	require.NoError(t, os.WriteFile(path, []byte("welcome\n"), 0644))
+
+	result := Snapshot(false)(path, "goodbye\n")
+
+	assert.False(t, result.IsPassed())
+	assert.Equal(t, "stale", result.(*SnapshotResult).Status)
+	output := result.Format()
+	assert.Contains(t, output, "FAIL: snapshot is stale")
+	assert.Contains(t, output, "  -welcome\n")
+	assert.Contains(t, output, "  +goodbye\n")
+	assert.Contains(t, output, "--update-snapshots")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "welcome\n", string(content))
+}
+
+func TestSnapshot_UpdateMode_RewritesDifferentSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "login.snap")
+	require.NoError(t, os.WriteFile(path, []byte("welcome\n"), 0644))
+
+	result := Snapshot(true)(path, "goodbye\n")
+
+	assert.True(t, result.IsPassed())
+	assert.Equal(t, "updated", result.(*SnapshotResult).Status)
+	assert.Equal(t, "PASS: snapshot updated at "+path+"\n", result.Format())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "goodbye\n", string(content))
+}
+
+func TestSnapshot_UnreadableSnapshot_Fails(t *testing.T) {
+	path := t.TempDir()
+
+	result := Snapshot(false)(path, "welcome\n")
+
+	assert.False(t, result.IsPassed())
+	assert.Contains(t, result.Format(), "FAIL: cannot use snapshot "+path)
+}
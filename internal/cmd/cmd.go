@@ -3,6 +3,8 @@ package cmd
 import (
 	"flag"
 	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -26,12 +28,18 @@ func (slice *stringSlice) Set(value string) error {
 }
 
 type Config struct {
-	SpecDir     string
-	Outputs     []string
-	Filter      string
-	ShowHelp    bool
-	ShowVersion bool
-	Verbose     bool
+	Command         string
+	SpecDir         string
+	From            string
+	Outputs         []string
+	Filter          string
+	Jobs            int
+	KillGrace       time.Duration
+	Slowest         int
+	UpdateSnapshots bool
+	ShowHelp        bool
+	ShowVersion     bool
+	Verbose         bool
 }
 
 type RunOptions struct {
@@ -40,13 +48,17 @@ type RunOptions struct {
 	Executor   executor.Executor
 	Stdout     io.Writer
 	OutputFS   fs.WritableFS
+	Interrupts <-chan os.Signal
 }
 
 type RunResult struct {
-	Success bool
-	Passed  int
-	Failed  int
-	Error   error
+	Success     bool
+	Interrupted bool
+	Passed      int
+	Failed      int
+	Skipped     int
+	Flaky       int
+	Error       error
 }
 
 func Run(opts RunOptions) RunResult {
@@ -60,43 +72,85 @@ func Run(opts RunOptions) RunResult {
 	runID := time.Now().Format("2006-01-02_150405")
 	var sinks []sink.Sink
 	for _, output := range opts.Config.Outputs {
-		sinks = append(sinks, createSink(output, opts, runID))
+		sinks = append(sinks, createSink(output, opts, runID, specTree))
 	}
 	specRunner := runner.NewRunner(opts.Executor, sinks...)
 	specRunner.Filter = opts.Config.Filter
+	specRunner.Jobs = opts.Config.Jobs
+	specRunner.UpdateSnapshots = opts.Config.UpdateSnapshots
 	absSpecRootPath, err := opts.FileSystem.Abs(opts.Config.SpecDir)
 	if err != nil {
 		return RunResult{Error: err}
 	}
+	done := make(chan struct{})
+	go forwardInterrupts(opts.Interrupts, specRunner, done)
 	err = specRunner.RunWithID(runID, specTree, absSpecRootPath)
+	close(done)
+	interrupted := specRunner.Interruption() == "signal"
 	return RunResult{
-		Success: specRunner.Failed() == 0 && err == nil,
-		Passed:  specRunner.Passed(),
-		Failed:  specRunner.Failed(),
-		Error:   err,
+		Success:     specRunner.Failed() == 0 && specRunner.HookErrors() == 0 && err == nil && !interrupted,
+		Interrupted: interrupted,
+		Passed:      specRunner.Passed(),
+		Failed:      specRunner.Failed(),
+		Skipped:     specRunner.Skipped(),
+		Flaky:       specRunner.Flaky(),
+		Error:       err,
 	}
 }
 
-var writerSinks = map[string]func(io.Writer) sink.Sink{
-	"json":  sink.NewJsonStreamSink,
-	"junit": sink.NewJunitSink,
+func forwardInterrupts(interrupts <-chan os.Signal, specRunner *runner.Runner, done <-chan struct{}) {
+	for {
+		select {
+		case signal := <-interrupts:
+			specRunner.Interrupt(signal.String())
+		case <-done:
+			return
+		}
+	}
+}
+
+var writerSinks = map[string]func(io.Writer, *tree.SpecTree) sink.Sink{
+	"json":  withoutTree(sink.NewJsonStreamSink),
+	"junit": withoutTree(sink.NewJunitSink),
+	"tap":   sink.NewTapSink,
+}
+
+func withoutTree(factory func(io.Writer) sink.Sink) func(io.Writer, *tree.SpecTree) sink.Sink {
+	return func(writer io.Writer, _ *tree.SpecTree) sink.Sink {
+		return factory(writer)
+	}
 }
 
-func createSink(output string, opts RunOptions, runID string) sink.Sink {
+func createSink(output string, opts RunOptions, runID string, specTree *tree.SpecTree) sink.Sink {
+	if output == "json:v1" {
+		return sink.NewLegacyJsonStreamSink(opts.Stdout)
+	}
 	for prefix, factory := range writerSinks {
 		if strings.HasPrefix(output, prefix) {
-			return factory(opts.Stdout)
+			return factory(opts.Stdout, specTree)
 		}
 	}
 	if strings.HasPrefix(output, "cli") {
-		return cli.NewReporter(opts.Stdout, opts.Config.Verbose, true)
+		return cli.NewReporter(opts.Stdout, opts.Config.Verbose, true, opts.Config.Slowest)
 	}
 	if strings.HasPrefix(output, "files") {
 		return createFileSink(output, opts, runID)
 	}
+	if strings.HasPrefix(output, "html") {
+		return createHtmlSink(output, opts)
+	}
 	return nil
 }
 
+func createHtmlSink(output string, opts RunOptions) sink.Sink {
+	path := "report.html"
+	if _, after, found := strings.Cut(output, ":"); found {
+		path = after
+	}
+	writableFS := resolveWritableFS(opts.OutputFS, filepath.Dir(path))
+	return sink.NewHtmlSink(writableFS, filepath.Base(path))
+}
+
 func createFileSink(output string, opts RunOptions, runID string) sink.Sink {
 	path := extractFilesPath(output)
 	writableFS := resolveWritableFS(opts.OutputFS, path)
@@ -118,7 +172,11 @@ func resolveWritableFS(outputFS fs.WritableFS, path string) fs.WritableFS {
 }
 
 func ParseArgs(args []string) (*Config, error) {
-	config := &Config{}
+	config := &Config{Command: "run"}
+	if len(args) > 0 && (args[0] == "validate" || args[0] == "report") {
+		config.Command = args[0]
+		args = args[1:]
+	}
 
 	var outputs stringSlice
 	flags := flag.NewFlagSet("basanos", flag.ContinueOnError)
@@ -126,8 +184,14 @@ func ParseArgs(args []string) (*Config, error) {
 	flags.StringVar(&config.SpecDir, "spec", "spec", "spec directory")
 	flags.Var(&outputs, "o", "output sink")
 	flags.Var(&outputs, "output", "output sink")
+	flags.StringVar(&config.From, "from", "", "NDJSON event stream to replay")
 	flags.StringVar(&config.Filter, "f", "", "filter pattern")
 	flags.StringVar(&config.Filter, "filter", "", "filter pattern")
+	flags.IntVar(&config.Jobs, "j", 1, "parallel jobs")
+	flags.IntVar(&config.Jobs, "jobs", 1, "parallel jobs")
+	flags.DurationVar(&config.KillGrace, "kill-grace", executor.DefaultKillGrace, "grace period between SIGTERM and SIGKILL")
+	flags.IntVar(&config.Slowest, "slowest", 0, "report the N slowest scenarios")
+	flags.BoolVar(&config.UpdateSnapshots, "update-snapshots", false, "rewrite snapshots that differ")
 	flags.BoolVar(&config.ShowHelp, "h", false, "show help")
 	flags.BoolVar(&config.ShowHelp, "help", false, "show help")
 	flags.BoolVar(&config.ShowVersion, "v", false, "show version")
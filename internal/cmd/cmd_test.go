@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"basanos/internal/executor"
 	fakeexec "basanos/internal/testutil/executor"
 	memfs "basanos/internal/testutil/fs"
 
@@ -19,6 +22,11 @@ func TestParseArgs_DefaultValues(t *testing.T) {
 	assert.Equal(t, "spec", config.SpecDir)
 	assert.Equal(t, []string{"cli"}, config.Outputs)
 	assert.Equal(t, "", config.Filter)
+	assert.Equal(t, 1, config.Jobs)
+	assert.Equal(t, 5*time.Second, config.KillGrace)
+	assert.Equal(t, 0, config.Slowest)
+	assert.False(t, config.UpdateSnapshots)
+	assert.Equal(t, "run", config.Command)
 	assert.False(t, config.ShowHelp)
 	assert.False(t, config.ShowVersion)
 }
@@ -84,6 +92,47 @@ func TestParseArgs_FilterFlag(t *testing.T) {
 	}
 }
 
+func TestParseArgs_JobsFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"short form", []string{"-j", "4"}, 4},
+		{"long form", []string{"--jobs", "8"}, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := ParseArgs(tt.args)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, config.Jobs)
+		})
+	}
+}
+
+func TestParseArgs_KillGraceFlag(t *testing.T) {
+	config, err := ParseArgs([]string{"--kill-grace", "500ms"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 500*time.Millisecond, config.KillGrace)
+}
+
+func TestParseArgs_SlowestFlag(t *testing.T) {
+	config, err := ParseArgs([]string{"--slowest", "5"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, config.Slowest)
+}
+
+func TestParseArgs_UpdateSnapshotsFlag(t *testing.T) {
+	config, err := ParseArgs([]string{"--update-snapshots"})
+
+	require.NoError(t, err)
+	assert.True(t, config.UpdateSnapshots)
+}
+
 func TestParseArgs_HelpFlag(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -131,6 +180,24 @@ func TestParseArgs_VerboseFlag(t *testing.T) {
 	assert.True(t, config.Verbose)
 }
 
+func TestParseArgs_ValidateCommand(t *testing.T) {
+	config, err := ParseArgs([]string{"validate", "-s", "./my-specs", "-o", "json"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "validate", config.Command)
+	assert.Equal(t, "./my-specs", config.SpecDir)
+	assert.Equal(t, []string{"json"}, config.Outputs)
+}
+
+func TestParseArgs_ReportCommand(t *testing.T) {
+	config, err := ParseArgs([]string{"report", "--from", "events.ndjson", "-o", "junit", "-o", "html"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "report", config.Command)
+	assert.Equal(t, "events.ndjson", config.From)
+	assert.Equal(t, []string{"junit", "html"}, config.Outputs)
+}
+
 func TestParseArgs_InvalidFlag_ReturnsError(t *testing.T) {
 	_, err := ParseArgs([]string{"--invalid-flag"})
 
@@ -227,6 +294,60 @@ scenarios:
 	assert.Contains(t, buf.String(), "run_start")
 }
 
+func TestRun_UsesTapSink(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddDir("spec")
+	memFS.AddFile("spec/context.yaml", []byte(`name: "Test"
+scenarios:
+  - id: test
+    name: "Test scenario"
+    run:
+      command: "echo hello"
+      timeout: "10s"
+`))
+
+	var buf bytes.Buffer
+	opts := RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"tap"}},
+		FileSystem: memFS,
+		Executor:   &fakeexec.FakeExecutor{},
+		Stdout:     &buf,
+	}
+
+	result := Run(opts)
+
+	require.NoError(t, result.Error)
+	assert.Contains(t, buf.String(), "TAP version 14\n1..1\nok 1 - ")
+}
+
+func TestRun_JsonV1SinkUsesLegacyEventNames(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddDir("spec")
+	memFS.AddFile("spec/context.yaml", []byte(`name: "Test"
+scenarios:
+  - id: test
+    name: "Test scenario"
+    run:
+      command: "echo hello"
+      timeout: "10s"
+`))
+
+	var buf bytes.Buffer
+	opts := RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"json:v1"}},
+		FileSystem: memFS,
+		Executor:   &fakeexec.FakeExecutor{},
+		Stdout:     &buf,
+	}
+
+	result := Run(opts)
+
+	require.NoError(t, result.Error)
+	assert.Contains(t, buf.String(), `"schema_version":1`)
+	assert.Contains(t, buf.String(), `"event":"run_end","run_id":"`)
+	assert.NotContains(t, buf.String(), "scenario_run_start")
+}
+
 func TestRun_CreatesFileSink(t *testing.T) {
 	memFS := memfs.NewMemoryFS()
 	memFS.AddDir("spec")
@@ -263,6 +384,34 @@ scenarios:
 	assert.True(t, foundRunStdout, "Expected to find _run/stdout file, got: %v", files)
 }
 
+func TestRun_CreatesHtmlSink(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddDir("spec")
+	memFS.AddFile("spec/context.yaml", []byte(`name: "Test"
+scenarios:
+  - id: test
+    name: "Test scenario"
+    run:
+      command: "echo hello"
+      timeout: "10s"
+`))
+
+	outputFS := memfs.NewMemoryFS()
+	opts := RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"html:reports/run.html"}},
+		FileSystem: memFS,
+		Executor:   &fakeexec.FakeExecutor{},
+		OutputFS:   outputFS,
+	}
+
+	result := Run(opts)
+
+	require.NoError(t, result.Error)
+	content, err := outputFS.ReadFile("run.html")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Test scenario")
+}
+
 func TestRun_UsesJunitSink(t *testing.T) {
 	memFS := memfs.NewMemoryFS()
 	memFS.AddDir("spec")
@@ -345,6 +494,7 @@ scenarios:
 	require.NoError(t, result.Error)
 	require.Len(t, fakeExec.Commands, 1, "Filter should limit execution to one scenario")
 	assert.Equal(t, "echo first", fakeExec.Commands[0].Command)
+	assert.Equal(t, 1, result.Skipped)
 }
 
 func TestRun_VerboseFlagAffectsCLISink(t *testing.T) {
@@ -401,3 +551,88 @@ scenarios:
 
 	assert.False(t, result.Success)
 }
+
+func TestRun_ReturnsFailureWhenContextHookFails(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddDir("spec")
+	memFS.AddFile("spec/context.yaml", []byte(`name: "Test"
+after:
+  run: "./stop-server.sh"
+scenarios:
+  - id: test
+    name: "Test scenario"
+    run:
+      command: "echo hello"
+      timeout: "10s"
+`))
+
+	fakeExec := &fakeexec.FakeExecutor{
+		ExitCodes: map[string]int{"./stop-server.sh": 1},
+	}
+	opts := RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"files"}},
+		FileSystem: memFS,
+		Executor:   fakeExec,
+	}
+
+	result := Run(opts)
+
+	assert.Equal(t, 1, result.Passed)
+	assert.False(t, result.Success)
+}
+
+type signallingExecutor struct {
+	fakeexec.FakeExecutor
+	interrupts  chan os.Signal
+	interrupted chan struct{}
+}
+
+func (signaller *signallingExecutor) ExecuteStreaming(command string, timeout string, env map[string]string, stdin string, onOutput executor.OutputHandler) (string, string, int, error) {
+	if command == "echo first" {
+		signaller.interrupts <- os.Interrupt
+		<-signaller.interrupted
+	}
+	return signaller.FakeExecutor.Execute(command, timeout, env)
+}
+
+func (signaller *signallingExecutor) Interrupt() {
+	close(signaller.interrupted)
+}
+
+func TestRun_SignalInterruptsRunAndCompletesSinks(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddDir("spec")
+	memFS.AddFile("spec/context.yaml", []byte(`name: "Test"
+scenarios:
+  - id: first
+    name: "First"
+    run:
+      command: "echo first"
+      timeout: "10s"
+  - id: second
+    name: "Second"
+    run:
+      command: "echo second"
+      timeout: "10s"
+`))
+
+	interrupts := make(chan os.Signal, 1)
+	fakeExec := &signallingExecutor{interrupts: interrupts, interrupted: make(chan struct{})}
+	var buf bytes.Buffer
+	opts := RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"junit"}},
+		FileSystem: memFS,
+		Executor:   fakeExec,
+		Stdout:     &buf,
+		Interrupts: interrupts,
+	}
+
+	result := Run(opts)
+
+	require.NoError(t, result.Error)
+	assert.True(t, result.Interrupted)
+	assert.False(t, result.Success)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Contains(t, buf.String(), `<skipped message="interrupted">`)
+	assert.Contains(t, buf.String(), "</testsuites>")
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"basanos/internal/event"
+	"basanos/internal/sink"
+	"basanos/internal/tree"
+)
+
+type ReportResult struct {
+	Events     int
+	Incomplete bool
+	Error      error
+}
+
+func Report(opts RunOptions) ReportResult {
+	if opts.Config.From == "" {
+		return ReportResult{Error: errors.New("report needs --from FILE")}
+	}
+	data, err := opts.FileSystem.ReadFile(opts.Config.From)
+	if err != nil {
+		return ReportResult{Error: err}
+	}
+	events, err := decodeEvents(opts.Config.From, data)
+	if err != nil {
+		return ReportResult{Error: err}
+	}
+	runID := replayRunID(events)
+	sinks, err := replaySinks(opts, runID)
+	if err != nil {
+		return ReportResult{Error: err}
+	}
+	replayed := events
+	end := interruptedRunEnd(runID, events)
+	if end != nil {
+		replayed = append(replayed, end)
+	}
+	for _, incoming := range replayed {
+		for _, replaySink := range sinks {
+			if err := replaySink.Emit(incoming); err != nil {
+				return ReportResult{Error: err}
+			}
+		}
+	}
+	return ReportResult{Events: len(events), Incomplete: end != nil}
+}
+
+func interruptedRunEnd(runID string, events []any) *event.RunEndEvent {
+	var started, finished time.Time
+	var passed, failed, skipped, flaky int
+	for _, incoming := range events {
+		switch typed := incoming.(type) {
+		case *event.RunStartEvent:
+			started, finished = typed.Timestamp, typed.Timestamp
+		case *event.ScenarioExitEvent:
+			finished = typed.Timestamp
+			switch typed.Status {
+			case "retry":
+			case "pass":
+				passed++
+			case "flaky":
+				passed++
+				flaky++
+			default:
+				failed++
+			}
+		case *event.ScenarioSkippedEvent:
+			skipped++
+		case *event.ContextExitEvent:
+			finished = typed.Timestamp
+		case *event.RunEndEvent:
+			return nil
+		}
+	}
+	if started.IsZero() {
+		started = finished
+	}
+	return event.NewRunEndEvent(runID, "interrupted", passed, failed, skipped, flaky, finished, finished.Sub(started))
+}
+
+func decodeEvents(source string, data []byte) ([]any, error) {
+	var events []any
+	for index, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		decoded, err := event.Decode(line)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", source, index+1, err)
+		}
+		if decoded != nil {
+			events = append(events, decoded)
+		}
+	}
+	return events, nil
+}
+
+func replayRunID(events []any) string {
+	for _, incoming := range events {
+		if start, ok := incoming.(*event.RunStartEvent); ok && start.RunID != "" {
+			return start.RunID
+		}
+	}
+	return time.Now().Format("2006-01-02_150405")
+}
+
+func replaySinks(opts RunOptions, runID string) ([]sink.Sink, error) {
+	var sinks []sink.Sink
+	var specTree *tree.SpecTree
+	for _, output := range opts.Config.Outputs {
+		if strings.HasPrefix(output, "tap") && specTree == nil {
+			loaded, err := tree.LoadSpecTree(opts.FileSystem, opts.Config.SpecDir)
+			if err != nil {
+				return nil, fmt.Errorf("output %q needs the spec tree: %w", output, err)
+			}
+			specTree = loaded
+		}
+		replaySink := createSink(output, opts, runID, specTree)
+		if replaySink == nil {
+			return nil, fmt.Errorf("unknown output %q", output)
+		}
+		sinks = append(sinks, replaySink)
+	}
+	return sinks, nil
+}
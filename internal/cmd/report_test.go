@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	fakeexec "basanos/internal/testutil/executor"
+	memfs "basanos/internal/testutil/fs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addRecordedSpec(memFS *memfs.MemoryFS) {
+	memFS.AddDir("spec")
+	memFS.AddFile("spec/context.yaml", []byte(`name: "Test"
+scenarios:
+  - id: test
+    name: "Test scenario"
+    run:
+      command: "echo hello"
+      timeout: "10s"
+`))
+}
+
+func recordedRun(t *testing.T) []byte {
+	memFS := memfs.NewMemoryFS()
+	addRecordedSpec(memFS)
+
+	var buf bytes.Buffer
+	result := Run(RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"json"}},
+		FileSystem: memFS,
+		Executor:   &fakeexec.FakeExecutor{},
+		Stdout:     &buf,
+	})
+	require.NoError(t, result.Error)
+	return buf.Bytes()
+}
+
+func TestReport_ReplaysRecordedEventsThroughSinks(t *testing.T) {
+	recorded := recordedRun(t)
+	memFS := memfs.NewMemoryFS()
+	memFS.AddFile("events.ndjson", recorded)
+
+	var buf bytes.Buffer
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", From: "events.ndjson", Outputs: []string{"json", "junit"}},
+		FileSystem: memFS,
+		Stdout:     &buf,
+	})
+
+	require.NoError(t, result.Error)
+	assert.Greater(t, result.Events, 0)
+	assert.Contains(t, buf.String(), string(recorded))
+	assert.Contains(t, buf.String(), `<testcase name="Test scenario" classname="spec"`)
+}
+
+func TestReport_WritesFileSinksUnderTheRecordedRunID(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddFile("events.ndjson", []byte(`{"event":"run_start","run_id":"2026-01-15_143022","schema_version":2,"timestamp":"2026-01-15T14:30:22Z"}
+{"event":"output","run_id":"2026-01-15_143022","path":"spec/test","phase":"_run","seq":1,"stream":"stdout","data":"hello\n"}
+`))
+	outputFS := memfs.NewMemoryFS()
+
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", From: "events.ndjson", Outputs: []string{"files"}},
+		FileSystem: memFS,
+		OutputFS:   outputFS,
+	})
+
+	require.NoError(t, result.Error)
+	content, err := outputFS.ReadFile("2026-01-15_143022/spec/test/_run/stdout")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(content))
+}
+
+func TestReport_TruncatedStreamIsReportedAsInterrupted(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddFile("events.ndjson", []byte(`{"event":"run_start","run_id":"run-1","schema_version":2,"timestamp":"2026-01-15T14:30:22Z"}
+{"event":"context_enter","run_id":"run-1","path":"spec","name":"Test","timestamp":"2026-01-15T14:30:22Z"}
+{"event":"scenario_enter","run_id":"run-1","path":"spec/login","name":"Login","attempt":1,"timestamp":"2026-01-15T14:30:22Z"}
+{"event":"scenario_exit","run_id":"run-1","path":"spec/login","status":"pass","attempt":1,"timestamp":"2026-01-15T14:30:24Z","duration_ms":2000}
+{"event":"scenario_enter","run_id":"run-1","path":"spec/logout","name":"Logout","attempt":1,"timestamp":"2026-01-15T14:30:24Z"}
+`))
+
+	var buf bytes.Buffer
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", From: "events.ndjson", Outputs: []string{"json", "junit"}},
+		FileSystem: memFS,
+		Stdout:     &buf,
+	})
+
+	require.NoError(t, result.Error)
+	assert.True(t, result.Incomplete)
+	assert.Equal(t, 5, result.Events)
+	assert.Contains(t, buf.String(), `{"event":"run_end","run_id":"run-1","status":"interrupted","passed":1,"failed":0,"skipped":0,"flaky":0,"timestamp":"2026-01-15T14:30:24Z","duration_ms":2000}`)
+	assert.Contains(t, buf.String(), `<testcase name="Login" classname="spec"`)
+	assert.Contains(t, buf.String(), `<error message="scenario did not finish" type="interrupted">`)
+}
+
+func TestReport_StreamWithoutContextEventsDoesNotPanic(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddFile("events.ndjson", []byte(`{"event":"scenario_exit","run_id":"run-1","path":"spec/login","status":"fail","attempt":1,"timestamp":"2026-01-15T14:30:22Z","duration_ms":0}
+`))
+	outputFS := memfs.NewMemoryFS()
+
+	var buf bytes.Buffer
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", From: "events.ndjson", Outputs: []string{"junit", "html"}},
+		FileSystem: memFS,
+		OutputFS:   outputFS,
+		Stdout:     &buf,
+	})
+
+	require.NoError(t, result.Error)
+	assert.True(t, result.Incomplete)
+	assert.Contains(t, buf.String(), `<testcase name="login" classname="spec"`)
+	content, err := outputFS.ReadFile("report.html")
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "spec/login")
+}
+
+func TestReport_SkipsUnknownEventsAndBlankLines(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddFile("events.ndjson", []byte(`{"event":"run_start","run_id":"run-1","schema_version":2,"timestamp":"2026-01-15T14:30:22Z"}
+
+{"event":"telemetry","run_id":"run-1","cpu":0.5}
+{"event":"run_end","run_id":"run-1","status":"pass","passed":0,"failed":0,"skipped":0,"flaky":0,"timestamp":"2026-01-15T14:30:22Z","duration_ms":0}
+`))
+
+	var buf bytes.Buffer
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", From: "events.ndjson", Outputs: []string{"json"}},
+		FileSystem: memFS,
+		Stdout:     &buf,
+	})
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, 2, result.Events)
+	assert.NotContains(t, buf.String(), "telemetry")
+}
+
+func TestReport_NamesTheLineOfMalformedJSON(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddFile("events.ndjson", []byte(`{"event":"run_start","run_id":"run-1"}
+{"event":
+`))
+
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", From: "events.ndjson", Outputs: []string{"cli"}},
+		FileSystem: memFS,
+		Stdout:     &bytes.Buffer{},
+	})
+
+	require.Error(t, result.Error)
+	assert.Contains(t, result.Error.Error(), "events.ndjson:2:")
+}
+
+func TestReport_RequiresFrom(t *testing.T) {
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", Outputs: []string{"cli"}},
+		FileSystem: memfs.NewMemoryFS(),
+	})
+
+	assert.EqualError(t, result.Error, "report needs --from FILE")
+}
+
+func TestReport_ReplaysTapWithTheSpecTree(t *testing.T) {
+	recorded := recordedRun(t)
+	memFS := memfs.NewMemoryFS()
+	addRecordedSpec(memFS)
+	memFS.AddFile("events.ndjson", recorded)
+
+	var buf bytes.Buffer
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", From: "events.ndjson", SpecDir: "spec", Outputs: []string{"tap"}},
+		FileSystem: memFS,
+		Stdout:     &buf,
+	})
+
+	require.NoError(t, result.Error)
+	assert.Equal(t, "TAP version 14\n1..1\nok 1 - Test scenario\n", buf.String())
+}
+
+func TestReport_TapNeedsTheSpecTree(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddFile("events.ndjson", []byte("\n"))
+
+	result := Report(RunOptions{
+		Config:     &Config{Command: "report", From: "events.ndjson", SpecDir: "spec", Outputs: []string{"tap"}},
+		FileSystem: memFS,
+	})
+
+	require.Error(t, result.Error)
+	assert.Contains(t, result.Error.Error(), `output "tap" needs the spec tree: `)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"basanos/internal/spec"
+	"basanos/internal/tree"
+)
+
+type ValidateResult struct {
+	Success bool
+	Errors  []spec.ValidationError
+	Error   error
+}
+
+type validationReport struct {
+	Valid  bool                   `json:"valid"`
+	Files  int                    `json:"files"`
+	Errors []spec.ValidationError `json:"errors"`
+}
+
+func Validate(opts RunOptions) ValidateResult {
+	errors, files, err := tree.ValidateSpecTree(opts.FileSystem, opts.Config.SpecDir)
+	if err != nil {
+		return ValidateResult{Error: err}
+	}
+	if wantsJSON(opts.Config.Outputs) {
+		err = writeValidationJSON(opts.Stdout, errors, files)
+	} else {
+		writeValidationText(opts.Stdout, errors, files)
+	}
+	return ValidateResult{Success: len(errors) == 0, Errors: errors, Error: err}
+}
+
+func wantsJSON(outputs []string) bool {
+	return slices.ContainsFunc(outputs, func(output string) bool {
+		return strings.HasPrefix(output, "json")
+	})
+}
+
+func writeValidationJSON(writer io.Writer, errors []spec.ValidationError, files int) error {
+	if errors == nil {
+		errors = []spec.ValidationError{}
+	}
+	return json.NewEncoder(writer).Encode(validationReport{Valid: len(errors) == 0, Files: files, Errors: errors})
+}
+
+func writeValidationText(writer io.Writer, errors []spec.ValidationError, files int) {
+	for _, validationError := range errors {
+		fmt.Fprintln(writer, validationError)
+	}
+	if len(errors) == 0 {
+		fmt.Fprintf(writer, "%s valid\n", plural(files, "context file"))
+		return
+	}
+	fmt.Fprintf(writer, "\n%s in %s\n", plural(len(errors), "error"), plural(files, "context file"))
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	fakeexec "basanos/internal/testutil/executor"
+	memfs "basanos/internal/testutil/fs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func invalidSpecTree() *memfs.MemoryFS {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddDir("spec")
+	memFS.AddDir("spec/child")
+	memFS.AddFile("spec/context.yaml", []byte(`name: "Root"
+on_failure: explode
+scenarios:
+  - name: "Missing ID"
+    run:
+      command: echo hello
+`))
+	memFS.AddFile("spec/child/context.yaml", []byte(`name: "Child"
+scenarios:
+  - id: slow
+    run:
+      command: sleep 1
+      timeout: forever
+`))
+	return memFS
+}
+
+func TestValidate_ReportsEveryErrorInCompilerFormat(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	fakeExec := &fakeexec.FakeExecutor{}
+	opts := RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"cli"}},
+		FileSystem: invalidSpecTree(),
+		Executor:   fakeExec,
+		Stdout:     buffer,
+	}
+
+	result := Validate(opts)
+
+	require.NoError(t, result.Error)
+	assert.False(t, result.Success)
+	assert.Empty(t, fakeExec.Commands)
+	expected := `spec/context.yaml:2:13: on_failure: must be skip_children, continue, or abort_run
+spec/context.yaml:4:5: scenarios[0].id: required
+spec/child/context.yaml:6:16: scenarios[0].run.timeout: invalid duration
+
+3 errors in 2 context files
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestValidate_WritesJSONReport(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	opts := RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"json"}},
+		FileSystem: invalidSpecTree(),
+		Stdout:     buffer,
+	}
+
+	Validate(opts)
+
+	var report struct {
+		Valid  bool `json:"valid"`
+		Files  int  `json:"files"`
+		Errors []struct {
+			File    string `json:"file"`
+			Line    int    `json:"line"`
+			Column  int    `json:"column"`
+			Path    string `json:"path"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &report))
+	assert.False(t, report.Valid)
+	assert.Equal(t, 2, report.Files)
+	require.Len(t, report.Errors, 3)
+	assert.Equal(t, "spec/child/context.yaml", report.Errors[2].File)
+	assert.Equal(t, 6, report.Errors[2].Line)
+	assert.Equal(t, 16, report.Errors[2].Column)
+	assert.Equal(t, "scenarios[0].run.timeout", report.Errors[2].Path)
+}
+
+func TestValidate_SucceedsForValidTree(t *testing.T) {
+	memFS := memfs.NewMemoryFS()
+	memFS.AddDir("spec")
+	memFS.AddFile("spec/context.yaml", []byte(`name: "Root"`))
+	buffer := &bytes.Buffer{}
+	opts := RunOptions{
+		Config:     &Config{SpecDir: "spec", Outputs: []string{"cli"}},
+		FileSystem: memFS,
+		Stdout:     buffer,
+	}
+
+	result := Validate(opts)
+
+	require.NoError(t, result.Error)
+	assert.True(t, result.Success)
+	assert.Equal(t, "1 context file valid\n", buffer.String())
+}
@@ -0,0 +1,69 @@
+package event
+
+import "encoding/json"
+
+var eventTypes = map[string]func() any{
+	"run_start":          func() any { return &RunStartEvent{} },
+	"context_enter":      func() any { return &ContextEnterEvent{} },
+	"context_exit":       func() any { return &ContextExitEvent{} },
+	"hook_start":         func() any { return &HookStartEvent{} },
+	"hook_end":           func() any { return &HookEndEvent{} },
+	"scenario_enter":     func() any { return &ScenarioEnterEvent{} },
+	"scenario_exit":      func() any { return &ScenarioExitEvent{} },
+	"scenario_skipped":   func() any { return &ScenarioSkippedEvent{} },
+	"scenario_run_start": func() any { return &ScenarioRunStartEvent{} },
+	"scenario_run_end":   func() any { return &ScenarioRunEndEvent{} },
+	"output":             func() any { return &OutputEvent{} },
+	"output_normalized":  func() any { return &OutputNormalizedEvent{} },
+	"assertion_start":    func() any { return &AssertionStartEvent{} },
+	"assertion_end":      func() any { return &AssertionEndEvent{} },
+	"timeout":            func() any { return &TimeoutEvent{} },
+	"service_start":      func() any { return &ServiceStartEvent{} },
+	"service_ready":      func() any { return &ServiceReadyEvent{} },
+	"service_stop":       func() any { return &ServiceStopEvent{} },
+	"process_leak":       func() any { return &ProcessLeakEvent{} },
+	"snapshot":           func() any { return &SnapshotEvent{} },
+	"run_interrupted":    func() any { return &RunInterruptedEvent{} },
+	"run_end":            func() any { return &RunEndEvent{} },
+}
+
+type discriminator struct {
+	Event string `json:"event"`
+	Path  string `json:"path"`
+}
+
+func Decode(data []byte) (any, error) {
+	var header discriminator
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	name := currentEventName(header)
+	newEvent, known := eventTypes[name]
+	if !known {
+		return nil, nil
+	}
+	decoded := newEvent()
+	if err := json.Unmarshal(data, decoded); err != nil {
+		return nil, err
+	}
+	switch typed := decoded.(type) {
+	case *ScenarioRunStartEvent:
+		typed.Event = name
+	case *ScenarioRunEndEvent:
+		typed.Event = name
+	}
+	return decoded, nil
+}
+
+func currentEventName(header discriminator) string {
+	if header.Path == "" {
+		return header.Event
+	}
+	switch header.Event {
+	case "run_start":
+		return "scenario_run_start"
+	case "run_end":
+		return "scenario_run_end"
+	}
+	return header.Event
+}
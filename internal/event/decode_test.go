@@ -0,0 +1,81 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_RoundTripsEveryEventType(t *testing.T) {
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	events := []any{
+		NewRunStartEvent("run-1", timestamp),
+		NewContextEnterEvent("run-1", "api", "API Tests", timestamp),
+		NewContextExitEvent("run-1", "api", timestamp, time.Second),
+		NewHookStartEvent("run-1", "api", "_before", "", timestamp),
+		NewHookEndEvent("run-1", "api", "_before", "", 1, time.Second),
+		NewScenarioEnterEvent("run-1", "api/login", "Login", 2, timestamp),
+		NewScenarioExitEvent("run-1", "api/login", "flaky", 2, timestamp, time.Second),
+		NewScenarioSkippedEvent("run-1", "api/logout", "Logout", "filter", timestamp),
+		NewScenarioRunStartEvent("run-1", "api/login", timestamp),
+		NewScenarioRunEndEvent("run-1", "api/login", 3, time.Second),
+		NewOutputEvent("run-1", "api/login", "_run", 7, "stderr", "oops\n"),
+		NewOutputNormalizedEvent("run-1", "api/login", "_run", "stdout", "at <TIMESTAMP>\n"),
+		NewAssertionStartEvent("run-1", "api/login", 0, "assert_equals 0 1", timestamp),
+		NewAssertionEndEvent("run-1", "api/login", 0, 1, 1, "", time.Second),
+		NewTimeoutEvent("run-1", "api/login", "run", "5s"),
+		NewServiceStartEvent("run-1", "api", "db", "postgres", timestamp),
+		NewServiceReadyEvent("run-1", "api", "db"),
+		NewServiceStopEvent("run-1", "api", "db", "crashed", 2),
+		NewProcessLeakEvent("run-1", "api/login", "_run", 100, []int{101, 102}),
+		NewSnapshotEvent("run-1", "api/login", 1, "spec/__snapshots__/login.snap", "stale"),
+		NewRunInterruptedEvent("run-1", "signal", "interrupt", timestamp),
+		NewRunEndEvent("run-1", "fail", 1, 2, 3, 4, timestamp, time.Second),
+	}
+
+	for _, original := range events {
+		data, err := json.Marshal(original)
+		require.NoError(t, err)
+
+		decoded, err := Decode(data)
+
+		require.NoError(t, err)
+		assert.Equal(t, original, decoded)
+	}
+}
+
+func TestDecode_IgnoresUnknownEventTypes(t *testing.T) {
+	decoded, err := Decode([]byte(`{"event":"coffee_break","run_id":"run-1","minutes":5}`))
+
+	require.NoError(t, err)
+	assert.Nil(t, decoded)
+}
+
+func TestDecode_IgnoresUnknownFields(t *testing.T) {
+	decoded, err := Decode([]byte(`{"event":"context_enter","run_id":"run-1","path":"api","name":"API","owner":"qa"}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, "API", decoded.(*ContextEnterEvent).Name)
+}
+
+func TestDecode_MapsVersionOneScenarioRunEvents(t *testing.T) {
+	start, err := Decode([]byte(`{"event":"run_start","run_id":"run-1","path":"api/login","timestamp":"2026-01-15T14:30:22Z"}`))
+	require.NoError(t, err)
+	end, err := Decode([]byte(`{"event":"run_end","run_id":"run-1","path":"api/login","exit_code":3,"duration_ms":0}`))
+	require.NoError(t, err)
+
+	require.IsType(t, &ScenarioRunStartEvent{}, start)
+	assert.Equal(t, "scenario_run_start", start.(*ScenarioRunStartEvent).Event)
+	require.IsType(t, &ScenarioRunEndEvent{}, end)
+	assert.Equal(t, "scenario_run_end", end.(*ScenarioRunEndEvent).Event)
+	assert.Equal(t, 3, end.(*ScenarioRunEndEvent).ExitCode)
+}
+
+func TestDecode_RejectsMalformedJSON(t *testing.T) {
+	_, err := Decode([]byte(`{"event":`))
+
+	assert.Error(t, err)
+}
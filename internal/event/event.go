@@ -2,6 +2,8 @@ package event
 
 import "time"
 
+const SchemaVersion = 2
+
 type BaseEvent struct {
 	Event string `json:"event"`
 	RunID string `json:"run_id,omitempty"`
@@ -9,13 +11,15 @@ type BaseEvent struct {
 
 type RunStartEvent struct {
 	BaseEvent
-	Timestamp time.Time `json:"timestamp"`
+	SchemaVersion int       `json:"schema_version"`
+	Timestamp     time.Time `json:"timestamp"`
 }
 
 func NewRunStartEvent(runID string, timestamp time.Time) *RunStartEvent {
 	return &RunStartEvent{
-		BaseEvent: BaseEvent{Event: "run_start", RunID: runID},
-		Timestamp: timestamp,
+		BaseEvent:     BaseEvent{Event: "run_start", RunID: runID},
+		SchemaVersion: SchemaVersion,
+		Timestamp:     timestamp,
 	}
 }
 
@@ -37,49 +41,55 @@ func NewContextEnterEvent(runID, path, name string, timestamp time.Time) *Contex
 
 type ContextExitEvent struct {
 	BaseEvent
-	Path      string    `json:"path"`
-	Timestamp time.Time `json:"timestamp"`
+	Path       string    `json:"path"`
+	Timestamp  time.Time `json:"timestamp"`
+	DurationMs int64     `json:"duration_ms"`
 }
 
-func NewContextExitEvent(runID, path string, timestamp time.Time) *ContextExitEvent {
+func NewContextExitEvent(runID, path string, timestamp time.Time, duration time.Duration) *ContextExitEvent {
 	return &ContextExitEvent{
-		BaseEvent: BaseEvent{Event: "context_exit", RunID: runID},
-		Path:      path,
-		Timestamp: timestamp,
+		BaseEvent:  BaseEvent{Event: "context_exit", RunID: runID},
+		Path:       path,
+		Timestamp:  timestamp,
+		DurationMs: duration.Milliseconds(),
 	}
 }
 
 type HookStartEvent struct {
 	BaseEvent
-	Path string `json:"path"`
-	Hook string `json:"hook"`
-	From string `json:"from,omitempty"`
+	Path      string    `json:"path"`
+	Hook      string    `json:"hook"`
+	From      string    `json:"from,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
-func NewHookStartEvent(runID, path, hook, from string) *HookStartEvent {
+func NewHookStartEvent(runID, path, hook, from string, timestamp time.Time) *HookStartEvent {
 	return &HookStartEvent{
 		BaseEvent: BaseEvent{Event: "hook_start", RunID: runID},
 		Path:      path,
 		Hook:      hook,
 		From:      from,
+		Timestamp: timestamp,
 	}
 }
 
 type HookEndEvent struct {
 	BaseEvent
-	Path     string `json:"path"`
-	Hook     string `json:"hook"`
-	From     string `json:"from,omitempty"`
-	ExitCode int    `json:"exit_code"`
+	Path       string `json:"path"`
+	Hook       string `json:"hook"`
+	From       string `json:"from,omitempty"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
 }
 
-func NewHookEndEvent(runID, path, hook, from string, exitCode int) *HookEndEvent {
+func NewHookEndEvent(runID, path, hook, from string, exitCode int, duration time.Duration) *HookEndEvent {
 	return &HookEndEvent{
-		BaseEvent: BaseEvent{Event: "hook_end", RunID: runID},
-		Path:      path,
-		Hook:      hook,
-		From:      from,
-		ExitCode:  exitCode,
+		BaseEvent:  BaseEvent{Event: "hook_end", RunID: runID},
+		Path:       path,
+		Hook:       hook,
+		From:       from,
+		ExitCode:   exitCode,
+		DurationMs: duration.Milliseconds(),
 	}
 }
 
@@ -87,69 +97,121 @@ type ScenarioEnterEvent struct {
 	BaseEvent
 	Path      string    `json:"path"`
 	Name      string    `json:"name"`
+	Attempt   int       `json:"attempt"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
-func NewScenarioEnterEvent(runID, path, name string, timestamp time.Time) *ScenarioEnterEvent {
+func NewScenarioEnterEvent(runID, path, name string, attempt int, timestamp time.Time) *ScenarioEnterEvent {
 	return &ScenarioEnterEvent{
 		BaseEvent: BaseEvent{Event: "scenario_enter", RunID: runID},
 		Path:      path,
 		Name:      name,
+		Attempt:   attempt,
 		Timestamp: timestamp,
 	}
 }
 
 type ScenarioExitEvent struct {
+	BaseEvent
+	Path       string    `json:"path"`
+	Status     string    `json:"status"`
+	Attempt    int       `json:"attempt"`
+	Timestamp  time.Time `json:"timestamp"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+func NewScenarioExitEvent(runID, path, status string, attempt int, timestamp time.Time, duration time.Duration) *ScenarioExitEvent {
+	return &ScenarioExitEvent{
+		BaseEvent:  BaseEvent{Event: "scenario_exit", RunID: runID},
+		Path:       path,
+		Status:     status,
+		Attempt:    attempt,
+		Timestamp:  timestamp,
+		DurationMs: duration.Milliseconds(),
+	}
+}
+
+type ScenarioSkippedEvent struct {
 	BaseEvent
 	Path      string    `json:"path"`
-	Status    string    `json:"status"`
+	Name      string    `json:"name"`
+	Reason    string    `json:"reason"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
-func NewScenarioExitEvent(runID, path, status string, timestamp time.Time) *ScenarioExitEvent {
-	return &ScenarioExitEvent{
-		BaseEvent: BaseEvent{Event: "scenario_exit", RunID: runID},
+func NewScenarioSkippedEvent(runID, path, name, reason string, timestamp time.Time) *ScenarioSkippedEvent {
+	return &ScenarioSkippedEvent{
+		BaseEvent: BaseEvent{Event: "scenario_skipped", RunID: runID},
 		Path:      path,
-		Status:    status,
+		Name:      name,
+		Reason:    reason,
 		Timestamp: timestamp,
 	}
 }
 
 type ScenarioRunStartEvent struct {
 	BaseEvent
-	Path string `json:"path"`
+	Path      string    `json:"path"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
-func NewScenarioRunStartEvent(runID, path string) *ScenarioRunStartEvent {
+func NewScenarioRunStartEvent(runID, path string, timestamp time.Time) *ScenarioRunStartEvent {
 	return &ScenarioRunStartEvent{
-		BaseEvent: BaseEvent{Event: "run_start", RunID: runID},
+		BaseEvent: BaseEvent{Event: "scenario_run_start", RunID: runID},
 		Path:      path,
+		Timestamp: timestamp,
 	}
 }
 
 type ScenarioRunEndEvent struct {
 	BaseEvent
-	Path     string `json:"path"`
-	ExitCode int    `json:"exit_code"`
+	Path       string `json:"path"`
+	ExitCode   int    `json:"exit_code"`
+	DurationMs int64  `json:"duration_ms"`
 }
 
-func NewScenarioRunEndEvent(runID, path string, exitCode int) *ScenarioRunEndEvent {
+func NewScenarioRunEndEvent(runID, path string, exitCode int, duration time.Duration) *ScenarioRunEndEvent {
 	return &ScenarioRunEndEvent{
-		BaseEvent: BaseEvent{Event: "run_end", RunID: runID},
-		Path:      path,
-		ExitCode:  exitCode,
+		BaseEvent:  BaseEvent{Event: "scenario_run_end", RunID: runID},
+		Path:       path,
+		ExitCode:   exitCode,
+		DurationMs: duration.Milliseconds(),
 	}
 }
 
 type OutputEvent struct {
 	BaseEvent
+	Path   string `json:"path"`
+	Phase  string `json:"phase"`
+	Seq    int64  `json:"seq"`
 	Stream string `json:"stream"`
 	Data   string `json:"data"`
 }
 
-func NewOutputEvent(runID, stream, data string) *OutputEvent {
+func NewOutputEvent(runID, path, phase string, seq int64, stream, data string) *OutputEvent {
 	return &OutputEvent{
 		BaseEvent: BaseEvent{Event: "output", RunID: runID},
+		Path:      path,
+		Phase:     phase,
+		Seq:       seq,
+		Stream:    stream,
+		Data:      data,
+	}
+}
+
+type OutputNormalizedEvent struct {
+	BaseEvent
+	Path   string `json:"path"`
+	Phase  string `json:"phase"`
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+func NewOutputNormalizedEvent(runID, path, phase, stream, data string) *OutputNormalizedEvent {
+	return &OutputNormalizedEvent{
+		BaseEvent: BaseEvent{Event: "output_normalized", RunID: runID},
+		Path:      path,
+		Phase:     phase,
 		Stream:    stream,
 		Data:      data,
 	}
@@ -157,33 +219,41 @@ func NewOutputEvent(runID, stream, data string) *OutputEvent {
 
 type AssertionStartEvent struct {
 	BaseEvent
-	Path    string `json:"path"`
-	Index   int    `json:"index"`
-	Command string `json:"command"`
+	Path      string    `json:"path"`
+	Index     int       `json:"index"`
+	Command   string    `json:"command"`
+	Timestamp time.Time `json:"timestamp"`
 }
 
-func NewAssertionStartEvent(runID, path string, index int, command string) *AssertionStartEvent {
+func NewAssertionStartEvent(runID, path string, index int, command string, timestamp time.Time) *AssertionStartEvent {
 	return &AssertionStartEvent{
 		BaseEvent: BaseEvent{Event: "assertion_start", RunID: runID},
 		Path:      path,
 		Index:     index,
 		Command:   command,
+		Timestamp: timestamp,
 	}
 }
 
 type AssertionEndEvent struct {
 	BaseEvent
-	Path     string `json:"path"`
-	Index    int    `json:"index"`
-	ExitCode int    `json:"exit_code"`
+	Path       string `json:"path"`
+	Index      int    `json:"index"`
+	ExitCode   int    `json:"exit_code"`
+	Attempts   int    `json:"attempts"`
+	Diagnostic string `json:"diagnostic,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
 }
 
-func NewAssertionEndEvent(runID, path string, index int, exitCode int) *AssertionEndEvent {
+func NewAssertionEndEvent(runID, path string, index int, exitCode int, attempts int, diagnostic string, duration time.Duration) *AssertionEndEvent {
 	return &AssertionEndEvent{
-		BaseEvent: BaseEvent{Event: "assertion_end", RunID: runID},
-		Path:      path,
-		Index:     index,
-		ExitCode:  exitCode,
+		BaseEvent:  BaseEvent{Event: "assertion_end", RunID: runID},
+		Path:       path,
+		Index:      index,
+		ExitCode:   exitCode,
+		Attempts:   attempts,
+		Diagnostic: diagnostic,
+		DurationMs: duration.Milliseconds(),
 	}
 }
 
@@ -203,20 +273,128 @@ func NewTimeoutEvent(runID, path, phase, limit string) *TimeoutEvent {
 	}
 }
 
-type RunEndEvent struct {
+type ServiceStartEvent struct {
 	BaseEvent
-	Status    string    `json:"status"`
-	Passed    int       `json:"passed"`
-	Failed    int       `json:"failed"`
+	Path      string    `json:"path"`
+	Service   string    `json:"service"`
+	Command   string    `json:"command"`
 	Timestamp time.Time `json:"timestamp"`
 }
 
-func NewRunEndEvent(runID, status string, passed, failed int, timestamp time.Time) *RunEndEvent {
-	return &RunEndEvent{
-		BaseEvent: BaseEvent{Event: "run_end", RunID: runID},
+func NewServiceStartEvent(runID, path, service, command string, timestamp time.Time) *ServiceStartEvent {
+	return &ServiceStartEvent{
+		BaseEvent: BaseEvent{Event: "service_start", RunID: runID},
+		Path:      path,
+		Service:   service,
+		Command:   command,
+		Timestamp: timestamp,
+	}
+}
+
+type ServiceReadyEvent struct {
+	BaseEvent
+	Path    string `json:"path"`
+	Service string `json:"service"`
+}
+
+func NewServiceReadyEvent(runID, path, service string) *ServiceReadyEvent {
+	return &ServiceReadyEvent{
+		BaseEvent: BaseEvent{Event: "service_ready", RunID: runID},
+		Path:      path,
+		Service:   service,
+	}
+}
+
+type ServiceStopEvent struct {
+	BaseEvent
+	Path     string `json:"path"`
+	Service  string `json:"service"`
+	Reason   string `json:"reason"`
+	ExitCode int    `json:"exit_code"`
+}
+
+func NewServiceStopEvent(runID, path, service, reason string, exitCode int) *ServiceStopEvent {
+	return &ServiceStopEvent{
+		BaseEvent: BaseEvent{Event: "service_stop", RunID: runID},
+		Path:      path,
+		Service:   service,
+		Reason:    reason,
+		ExitCode:  exitCode,
+	}
+}
+
+type ProcessLeakEvent struct {
+	BaseEvent
+	Path  string `json:"path"`
+	Phase string `json:"phase"`
+	PGID  int    `json:"pgid"`
+	PIDs  []int  `json:"pids"`
+}
+
+func NewProcessLeakEvent(runID, path, phase string, pgid int, pids []int) *ProcessLeakEvent {
+	return &ProcessLeakEvent{
+		BaseEvent: BaseEvent{Event: "process_leak", RunID: runID},
+		Path:      path,
+		Phase:     phase,
+		PGID:      pgid,
+		PIDs:      pids,
+	}
+}
+
+type SnapshotEvent struct {
+	BaseEvent
+	Path   string `json:"path"`
+	Index  int    `json:"index"`
+	File   string `json:"file"`
+	Status string `json:"status"`
+}
+
+func NewSnapshotEvent(runID, path string, index int, file, status string) *SnapshotEvent {
+	return &SnapshotEvent{
+		BaseEvent: BaseEvent{Event: "snapshot", RunID: runID},
+		Path:      path,
+		Index:     index,
+		File:      file,
 		Status:    status,
-		Passed:    passed,
-		Failed:    failed,
+	}
+}
+
+type RunInterruptedEvent struct {
+	BaseEvent
+	Reason    string    `json:"reason"`
+	Detail    string    `json:"detail"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func NewRunInterruptedEvent(runID, reason, detail string, timestamp time.Time) *RunInterruptedEvent {
+	return &RunInterruptedEvent{
+		BaseEvent: BaseEvent{Event: "run_interrupted", RunID: runID},
+		Reason:    reason,
+		Detail:    detail,
 		Timestamp: timestamp,
 	}
 }
+
+type RunEndEvent struct {
+	BaseEvent
+	Status     string    `json:"status"`
+	Passed     int       `json:"passed"`
+	Failed     int       `json:"failed"`
+	Skipped    int       `json:"skipped"`
+	Flaky      int       `json:"flaky"`
+	Timestamp  time.Time `json:"timestamp"`
+	DurationMs int64     `json:"duration_ms"`
+}
+
+func NewRunEndEvent(runID, status string, passed, failed, skipped, flaky int, timestamp time.Time, duration time.Duration) *RunEndEvent {
+	return &RunEndEvent{
+		BaseEvent:  BaseEvent{Event: "run_end", RunID: runID},
+		Status:     status,
+		Passed:     passed,
+		Failed:     failed,
+		Skipped:    skipped,
+		Flaky:      flaky,
+		Timestamp:  timestamp,
+		DurationMs: duration.Milliseconds(),
+	}
+}
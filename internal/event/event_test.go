@@ -23,6 +23,7 @@ func TestRunStartEvent_JSON(t *testing.T) {
 
 	assert.Equal(t, "run_start", result["event"])
 	assert.Equal(t, "2026-01-15_143022", result["run_id"])
+	assert.Equal(t, float64(SchemaVersion), result["schema_version"])
 	assert.Equal(t, "2026-01-15T14:30:22Z", result["timestamp"])
 }
 
@@ -48,7 +49,7 @@ func TestContextEnterEvent_JSON(t *testing.T) {
 func TestContextExitEvent_JSON(t *testing.T) {
 	timestamp := time.Date(2026, 1, 15, 14, 35, 45, 0, time.UTC)
 
-	event := NewContextExitEvent("run-123", "basic_http", timestamp)
+	event := NewContextExitEvent("run-123", "basic_http", timestamp, 2500*time.Millisecond)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -61,10 +62,13 @@ func TestContextExitEvent_JSON(t *testing.T) {
 	assert.Equal(t, "run-123", result["run_id"])
 	assert.Equal(t, "basic_http", result["path"])
 	assert.Equal(t, "2026-01-15T14:35:45Z", result["timestamp"])
+	assert.Equal(t, float64(2500), result["duration_ms"])
 }
 
 func TestHookStartEvent_JSON(t *testing.T) {
-	event := NewHookStartEvent("run-123", "basic_http", "before", "")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 23, 0, time.UTC)
+
+	event := NewHookStartEvent("run-123", "basic_http", "before", "", timestamp)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -78,10 +82,11 @@ func TestHookStartEvent_JSON(t *testing.T) {
 	assert.Equal(t, "basic_http", result["path"])
 	assert.Equal(t, "before", result["hook"])
 	assert.NotContains(t, string(data), "from")
+	assert.Equal(t, "2026-01-15T14:30:23Z", result["timestamp"])
 }
 
 func TestHookStartEvent_WithFrom_JSON(t *testing.T) {
-	event := NewHookStartEvent("run-123", "basic_http/login", "before_each", "basic_http")
+	event := NewHookStartEvent("run-123", "basic_http/login", "before_each", "basic_http", time.Time{})
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -98,7 +103,7 @@ func TestHookStartEvent_WithFrom_JSON(t *testing.T) {
 }
 
 func TestHookEndEvent_JSON(t *testing.T) {
-	event := NewHookEndEvent("run-123", "basic_http", "before", "", 0)
+	event := NewHookEndEvent("run-123", "basic_http", "before", "", 0, 1500*time.Millisecond)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -112,12 +117,13 @@ func TestHookEndEvent_JSON(t *testing.T) {
 	assert.Equal(t, "basic_http", result["path"])
 	assert.Equal(t, "before", result["hook"])
 	assert.Equal(t, float64(0), result["exit_code"])
+	assert.Equal(t, float64(1500), result["duration_ms"])
 }
 
 func TestScenarioEnterEvent_JSON(t *testing.T) {
 	timestamp := time.Date(2026, 1, 15, 14, 40, 10, 0, time.UTC)
 
-	event := NewScenarioEnterEvent("run-123", "basic_http/login", "Login works", timestamp)
+	event := NewScenarioEnterEvent("run-123", "basic_http/login", "Login works", 1, timestamp)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -136,7 +142,7 @@ func TestScenarioEnterEvent_JSON(t *testing.T) {
 func TestScenarioExitEvent_JSON(t *testing.T) {
 	timestamp := time.Date(2026, 1, 15, 14, 42, 30, 0, time.UTC)
 
-	event := NewScenarioExitEvent("run-123", "basic_http/login", "pass", timestamp)
+	event := NewScenarioExitEvent("run-123", "basic_http/login", "pass", 1, timestamp, 3200*time.Millisecond)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -150,10 +156,11 @@ func TestScenarioExitEvent_JSON(t *testing.T) {
 	assert.Equal(t, "basic_http/login", result["path"])
 	assert.Equal(t, "pass", result["status"])
 	assert.Equal(t, "2026-01-15T14:42:30Z", result["timestamp"])
+	assert.Equal(t, float64(3200), result["duration_ms"])
 }
 
 func TestOutputEvent_JSON(t *testing.T) {
-	event := NewOutputEvent("run-123", "stdout", "Hello world\n")
+	event := NewOutputEvent("run-123", "basic_http/login", "_run", 42, "stdout", "Hello world\n")
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -164,12 +171,35 @@ func TestOutputEvent_JSON(t *testing.T) {
 
 	assert.Equal(t, "output", result["event"])
 	assert.Equal(t, "run-123", result["run_id"])
+	assert.Equal(t, "basic_http/login", result["path"])
+	assert.Equal(t, "_run", result["phase"])
+	assert.Equal(t, float64(42), result["seq"])
 	assert.Equal(t, "stdout", result["stream"])
 	assert.Equal(t, "Hello world\n", result["data"])
 }
 
+func TestOutputNormalizedEvent_JSON(t *testing.T) {
+	event := NewOutputNormalizedEvent("run-123", "basic_http/login", "_run", "stdout", "id=<UUID>\n")
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "output_normalized", result["event"])
+	assert.Equal(t, "run-123", result["run_id"])
+	assert.Equal(t, "basic_http/login", result["path"])
+	assert.Equal(t, "_run", result["phase"])
+	assert.Equal(t, "stdout", result["stream"])
+	assert.Equal(t, "id=<UUID>\n", result["data"])
+}
+
 func TestAssertionStartEvent_JSON(t *testing.T) {
-	event := NewAssertionStartEvent("run-123", "basic_http/login", 0, "assert_equals 0 exit_code")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 24, 0, time.UTC)
+
+	event := NewAssertionStartEvent("run-123", "basic_http/login", 0, "assert_equals 0 exit_code", timestamp)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -183,10 +213,11 @@ func TestAssertionStartEvent_JSON(t *testing.T) {
 	assert.Equal(t, "basic_http/login", result["path"])
 	assert.Equal(t, float64(0), result["index"])
 	assert.Equal(t, "assert_equals 0 exit_code", result["command"])
+	assert.Equal(t, "2026-01-15T14:30:24Z", result["timestamp"])
 }
 
 func TestAssertionEndEvent_JSON(t *testing.T) {
-	event := NewAssertionEndEvent("run-123", "basic_http/login", 0, 0)
+	event := NewAssertionEndEvent("run-123", "basic_http/login", 0, 0, 1, "", 40*time.Millisecond)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -200,6 +231,23 @@ func TestAssertionEndEvent_JSON(t *testing.T) {
 	assert.Equal(t, "basic_http/login", result["path"])
 	assert.Equal(t, float64(0), result["index"])
 	assert.Equal(t, float64(0), result["exit_code"])
+	assert.Equal(t, float64(1), result["attempts"])
+	assert.NotContains(t, result, "diagnostic")
+	assert.Equal(t, float64(40), result["duration_ms"])
+}
+
+func TestAssertionEndEvent_JSON_WithPollingDiagnostic(t *testing.T) {
+	event := NewAssertionEndEvent("run-123", "jobs/finish", 0, 1, 4, "FAIL: substring not found\n", 3*time.Second)
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, float64(4), result["attempts"])
+	assert.Equal(t, "FAIL: substring not found\n", result["diagnostic"])
 }
 
 func TestTimeoutEvent_JSON(t *testing.T) {
@@ -219,10 +267,115 @@ func TestTimeoutEvent_JSON(t *testing.T) {
 	assert.Equal(t, "30s", result["limit"])
 }
 
+func TestServiceStartEvent_JSON(t *testing.T) {
+	timestamp := time.Date(2026, 1, 15, 14, 30, 26, 0, time.UTC)
+
+	event := NewServiceStartEvent("run-123", "api", "db", "./start-db.sh", timestamp)
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "service_start", result["event"])
+	assert.Equal(t, "api", result["path"])
+	assert.Equal(t, "db", result["service"])
+	assert.Equal(t, "./start-db.sh", result["command"])
+	assert.Equal(t, "2026-01-15T14:30:26Z", result["timestamp"])
+}
+
+func TestServiceReadyEvent_JSON(t *testing.T) {
+	event := NewServiceReadyEvent("run-123", "api", "db")
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "service_ready", result["event"])
+	assert.Equal(t, "api", result["path"])
+	assert.Equal(t, "db", result["service"])
+}
+
+func TestServiceStopEvent_JSON(t *testing.T) {
+	event := NewServiceStopEvent("run-123", "api", "db", "startup_timeout", -1)
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "service_stop", result["event"])
+	assert.Equal(t, "db", result["service"])
+	assert.Equal(t, "startup_timeout", result["reason"])
+	assert.Equal(t, float64(-1), result["exit_code"])
+}
+
+func TestProcessLeakEvent_JSON(t *testing.T) {
+	event := NewProcessLeakEvent("run-123", "api", "_before", 4242, []int{4242, 4250})
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "process_leak", result["event"])
+	assert.Equal(t, "run-123", result["run_id"])
+	assert.Equal(t, "api", result["path"])
+	assert.Equal(t, "_before", result["phase"])
+	assert.Equal(t, float64(4242), result["pgid"])
+	assert.Equal(t, []any{float64(4242), float64(4250)}, result["pids"])
+}
+
+func TestSnapshotEvent_JSON(t *testing.T) {
+	event := NewSnapshotEvent("run-123", "api/login", 2, "/specs/__snapshots__/login.snap", "new")
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "snapshot", result["event"])
+	assert.Equal(t, "run-123", result["run_id"])
+	assert.Equal(t, "api/login", result["path"])
+	assert.Equal(t, float64(2), result["index"])
+	assert.Equal(t, "/specs/__snapshots__/login.snap", result["file"])
+	assert.Equal(t, "new", result["status"])
+}
+
+func TestRunInterruptedEvent_JSON(t *testing.T) {
+	timestamp := time.Date(2026, 1, 15, 14, 40, 0, 0, time.UTC)
+
+	event := NewRunInterruptedEvent("2026-01-15_143022", "signal", "interrupt", timestamp)
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "run_interrupted", result["event"])
+	assert.Equal(t, "2026-01-15_143022", result["run_id"])
+	assert.Equal(t, "signal", result["reason"])
+	assert.Equal(t, "interrupt", result["detail"])
+	assert.Equal(t, "2026-01-15T14:40:00Z", result["timestamp"])
+}
+
 func TestRunEndEvent_JSON(t *testing.T) {
 	timestamp := time.Date(2026, 1, 15, 14, 45, 0, 0, time.UTC)
 
-	event := NewRunEndEvent("2026-01-15_143022", "fail", 12, 2, timestamp)
+	event := NewRunEndEvent("2026-01-15_143022", "fail", 12, 2, 3, 0, timestamp, 278000*time.Millisecond)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -236,11 +389,35 @@ func TestRunEndEvent_JSON(t *testing.T) {
 	assert.Equal(t, "fail", result["status"])
 	assert.Equal(t, float64(12), result["passed"])
 	assert.Equal(t, float64(2), result["failed"])
+	assert.Equal(t, float64(3), result["skipped"])
 	assert.Equal(t, "2026-01-15T14:45:00Z", result["timestamp"])
+	assert.Equal(t, float64(278000), result["duration_ms"])
+}
+
+func TestScenarioSkippedEvent_JSON(t *testing.T) {
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+
+	event := NewScenarioSkippedEvent("run-123", "basic_http/logout", "Logout", "skip_children", timestamp)
+
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	var result map[string]any
+	err = json.Unmarshal(data, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, "scenario_skipped", result["event"])
+	assert.Equal(t, "run-123", result["run_id"])
+	assert.Equal(t, "basic_http/logout", result["path"])
+	assert.Equal(t, "Logout", result["name"])
+	assert.Equal(t, "skip_children", result["reason"])
+	assert.Equal(t, "2026-01-15T14:30:22Z", result["timestamp"])
 }
 
 func TestScenarioRunStartEvent_JSON(t *testing.T) {
-	event := NewScenarioRunStartEvent("run-123", "basic_http/login")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 25, 0, time.UTC)
+
+	event := NewScenarioRunStartEvent("run-123", "basic_http/login", timestamp)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -249,13 +426,14 @@ func TestScenarioRunStartEvent_JSON(t *testing.T) {
 	err = json.Unmarshal(data, &result)
 	require.NoError(t, err)
 
-	assert.Equal(t, "run_start", result["event"])
+	assert.Equal(t, "scenario_run_start", result["event"])
 	assert.Equal(t, "run-123", result["run_id"])
 	assert.Equal(t, "basic_http/login", result["path"])
+	assert.Equal(t, "2026-01-15T14:30:25Z", result["timestamp"])
 }
 
 func TestScenarioRunEndEvent_JSON(t *testing.T) {
-	event := NewScenarioRunEndEvent("run-123", "basic_http/login", 0)
+	event := NewScenarioRunEndEvent("run-123", "basic_http/login", 0, 1250*time.Millisecond)
 
 	data, err := json.Marshal(event)
 	require.NoError(t, err)
@@ -264,8 +442,9 @@ func TestScenarioRunEndEvent_JSON(t *testing.T) {
 	err = json.Unmarshal(data, &result)
 	require.NoError(t, err)
 
-	assert.Equal(t, "run_end", result["event"])
+	assert.Equal(t, "scenario_run_end", result["event"])
 	assert.Equal(t, "run-123", result["run_id"])
 	assert.Equal(t, "basic_http/login", result["path"])
 	assert.Equal(t, float64(0), result["exit_code"])
+	assert.Equal(t, float64(1250), result["duration_ms"])
 }
@@ -1,22 +1,16 @@
 package event
 
 import (
-	"fmt"
 	"strconv"
 
 	"basanos/internal/sinkio"
 )
 
-func (e *ScenarioRunStartEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
-	w.SetCurrentPath(e.Path)
-	w.SetCurrentPhase("_run")
-	return nil
-}
-
-func (e *HookStartEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
-	w.SetCurrentPath(e.Path)
-	w.SetCurrentPhase(e.Hook)
-	return nil
+func (e *ScenarioEnterEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
+	if e.Attempt <= 1 {
+		return nil
+	}
+	return w.ClearOutput(e.Path)
 }
 
 func (e *HookEndEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
@@ -24,13 +18,15 @@ func (e *HookEndEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
 }
 
 func (e *OutputEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
-	return w.AppendOutput(e.Stream, e.Data)
+	return w.AppendOutput(e.Path, e.Phase, e.Stream, e.Data)
+}
+
+func (e *OutputNormalizedEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
+	return w.ReplaceOutput(e.Path, e.Phase, e.Stream, e.Data)
 }
 
-func (e *AssertionStartEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
-	w.SetCurrentPath(e.Path)
-	w.SetCurrentPhase(fmt.Sprintf("_assertions/%d", e.Index))
-	return nil
+func (e *ServiceStopEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
+	return w.WriteExitCode(e.Path, "_services/"+e.Service, e.ExitCode)
 }
 
 func (e *AssertionEndEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
@@ -38,7 +34,7 @@ func (e *AssertionEndEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
 }
 
 func (e *ScenarioRunEndEvent) WriteToFileSink(w sinkio.FileSinkWriter) error {
-	w.EnsureOutput("stdout")
-	w.EnsureOutput("stderr")
+	w.EnsureOutput(e.Path, "_run", "stdout")
+	w.EnsureOutput(e.Path, "_run", "stderr")
 	return w.WriteExitCode(e.Path, "_run", e.ExitCode)
 }
@@ -4,23 +4,53 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 )
 
 var ErrTimeout = errors.New("command timed out")
 
+var ErrInterrupted = errors.New("command interrupted")
+
+const DefaultKillGrace = 5 * time.Second
+
+type LeakError struct {
+	PGID int
+	PIDs []int
+}
+
+func (leak *LeakError) Error() string {
+	return fmt.Sprintf("process group %d still has running processes %v", leak.PGID, leak.PIDs)
+}
+
+type OutputHandler func(stream, data string)
+
 type Executor interface {
 	Execute(command string, timeout string, env map[string]string) (stdout, stderr string, exitCode int, err error)
 	ExecuteWithStdin(command string, timeout string, env map[string]string, stdin string) (stdout, stderr string, exitCode int, err error)
+	ExecuteStreaming(command string, timeout string, env map[string]string, stdin string, onOutput OutputHandler) (stdout, stderr string, exitCode int, err error)
+	Start(command string, env map[string]string, onOutput OutputHandler) (Process, error)
+	Interrupt()
 }
 
-type ShellExecutor struct{}
+type Process interface {
+	Done() <-chan struct{}
+	ExitCode() int
+	Stop() int
+}
+
+type ShellExecutor struct {
+	KillGrace time.Duration
+	mutex     sync.Mutex
+	running   map[*exec.Cmd]context.CancelFunc
+}
 
 func NewShellExecutor() *ShellExecutor {
-	return &ShellExecutor{}
+	return &ShellExecutor{KillGrace: DefaultKillGrace}
 }
 
 func (e *ShellExecutor) Execute(command string, timeout string, env map[string]string) (string, string, int, error) {
@@ -28,16 +58,112 @@ func (e *ShellExecutor) Execute(command string, timeout string, env map[string]s
 }
 
 func (e *ShellExecutor) ExecuteWithStdin(command string, timeout string, env map[string]string, stdin string) (string, string, int, error) {
+	return e.ExecuteStreaming(command, timeout, env, stdin, nil)
+}
+
+func (e *ShellExecutor) ExecuteStreaming(command string, timeout string, env map[string]string, stdin string, onOutput OutputHandler) (string, string, int, error) {
 	duration := parseDuration(timeout)
 	ctx, cancel := context.WithTimeout(context.Background(), duration)
 	defer cancel()
 	cmd := buildCommand(ctx, command, env)
-	var stdout, stderr bytes.Buffer
+	startProcessGroup(cmd, e.KillGrace)
+	defer e.track(cmd, cancel)()
+	var mutex sync.Mutex
+	stdout := &streamWriter{stream: "stdout", mutex: &mutex, onOutput: onOutput}
+	stderr := &streamWriter{stream: "stderr", mutex: &mutex, onOutput: onOutput}
 	cmd.Stdin = strings.NewReader(stdin)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 	err := cmd.Run()
-	return buildResult(ctx, err, stdout.String(), stderr.String())
+	return buildResult(ctx, cmd, err, stdout.String(), stderr.String())
+}
+
+func (e *ShellExecutor) Start(command string, env map[string]string, onOutput OutputHandler) (Process, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := buildCommand(ctx, command, env)
+	startProcessGroup(cmd, e.KillGrace)
+	var mutex sync.Mutex
+	cmd.Stdout = &streamWriter{stream: "stdout", mutex: &mutex, onOutput: onOutput}
+	cmd.Stderr = &streamWriter{stream: "stderr", mutex: &mutex, onOutput: onOutput}
+	if err := cmd.Start(); err != nil {
+		cancel()
+		return nil, err
+	}
+	process := &shellProcess{cmd: cmd, cancel: cancel, done: make(chan struct{})}
+	go process.wait()
+	return process, nil
+}
+
+func (e *ShellExecutor) Interrupt() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for _, cancel := range e.running {
+		cancel()
+	}
+}
+
+func (e *ShellExecutor) track(cmd *exec.Cmd, cancel context.CancelFunc) func() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.running == nil {
+		e.running = make(map[*exec.Cmd]context.CancelFunc)
+	}
+	e.running[cmd] = cancel
+	return func() {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+		delete(e.running, cmd)
+	}
+}
+
+type shellProcess struct {
+	cmd      *exec.Cmd
+	cancel   context.CancelFunc
+	done     chan struct{}
+	exitCode int
+}
+
+func (process *shellProcess) wait() {
+	process.cmd.Wait()
+	process.exitCode = process.cmd.ProcessState.ExitCode()
+	close(process.done)
+}
+
+func (process *shellProcess) Done() <-chan struct{} {
+	return process.done
+}
+
+func (process *shellProcess) ExitCode() int {
+	<-process.done
+	return process.exitCode
+}
+
+func (process *shellProcess) Stop() int {
+	process.cancel()
+	return process.ExitCode()
+}
+
+type streamWriter struct {
+	stream   string
+	buffer   bytes.Buffer
+	mutex    *sync.Mutex
+	onOutput OutputHandler
+}
+
+func (writer *streamWriter) Write(data []byte) (int, error) {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	writer.buffer.Write(data)
+	if writer.onOutput != nil {
+		writer.onOutput(writer.stream, string(data))
+	}
+	return len(data), nil
+}
+
+func (writer *streamWriter) String() string {
+	writer.mutex.Lock()
+	defer writer.mutex.Unlock()
+	return writer.buffer.String()
 }
 
 func parseDuration(timeout string) time.Duration {
@@ -57,15 +183,27 @@ func buildCommand(ctx context.Context, command string, env map[string]string) *e
 	return cmd
 }
 
-func buildResult(ctx context.Context, err error, stdout string, stderr string) (string, string, int, error) {
-	if err == nil {
-		return stdout, stderr, 0, nil
+func buildResult(ctx context.Context, cmd *exec.Cmd, err error, stdout string, stderr string) (string, string, int, error) {
+	if err == nil || errors.Is(err, exec.ErrWaitDelay) {
+		return stdout, stderr, 0, leakError(cmd)
 	}
 	if ctx.Err() == context.DeadlineExceeded {
 		return stdout, stderr, -1, ErrTimeout
 	}
+	if ctx.Err() == context.Canceled {
+		return stdout, stderr, -1, ErrInterrupted
+	}
 	if exitErr, ok := err.(*exec.ExitError); ok {
-		return stdout, stderr, exitErr.ExitCode(), nil
+		return stdout, stderr, exitErr.ExitCode(), leakError(cmd)
 	}
 	return stdout, stderr, -1, err
 }
+
+func leakError(cmd *exec.Cmd) error {
+	pgid := cmd.Process.Pid
+	pids, leaked := groupProcesses(pgid)
+	if !leaked {
+		return nil
+	}
+	return &LeakError{PGID: pgid, PIDs: pids}
+}
@@ -3,6 +3,7 @@ package executor
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -74,3 +75,49 @@ func TestExecuteWithStdin_CommandCanProcessStdin(t *testing.T) {
 	assert.Contains(t, stdout, "5")
 	assert.Empty(t, stderr)
 }
+
+func TestExecuteStreaming_DeliversChunksBeforeCommandExits(t *testing.T) {
+	exec := NewShellExecutor()
+	var firstChunkAt time.Time
+	var chunks []string
+
+	stdout, _, exitCode, err := exec.ExecuteStreaming("echo first; sleep 0.3; echo second", "5s", nil, "", func(stream, data string) {
+		if firstChunkAt.IsZero() {
+			firstChunkAt = time.Now()
+		}
+		chunks = append(chunks, data)
+	})
+	finishedAt := time.Now()
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, "first\nsecond\n", stdout)
+	assert.Equal(t, []string{"first\n", "second\n"}, chunks)
+	assert.GreaterOrEqual(t, finishedAt.Sub(firstChunkAt), 200*time.Millisecond)
+}
+
+func TestExecuteStreaming_TagsChunksWithStream(t *testing.T) {
+	exec := NewShellExecutor()
+	streams := map[string]string{}
+
+	_, stderr, _, err := exec.ExecuteStreaming("echo out; echo err >&2", "5s", nil, "", func(stream, data string) {
+		streams[stream] += data
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "err\n", stderr)
+	assert.Equal(t, map[string]string{"stdout": "out\n", "stderr": "err\n"}, streams)
+}
+
+func TestExecuteStreaming_DeliversPartialOutputOnTimeout(t *testing.T) {
+	exec := NewShellExecutor()
+	var received string
+
+	stdout, _, _, err := exec.ExecuteStreaming("echo started; sleep 1", "200ms", nil, "", func(stream, data string) {
+		received += data
+	})
+
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Equal(t, "started\n", received)
+	assert.Equal(t, "started\n", stdout)
+}
@@ -0,0 +1,16 @@
+//go:build !unix
+
+package executor
+
+import (
+	"os/exec"
+	"time"
+)
+
+func startProcessGroup(cmd *exec.Cmd, grace time.Duration) {
+	cmd.WaitDelay = max(grace, 100*time.Millisecond)
+}
+
+func groupProcesses(pgid int) ([]int, bool) {
+	return nil, false
+}
@@ -0,0 +1,71 @@
+//go:build unix
+
+package executor
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const minWaitDelay = 100 * time.Millisecond
+
+func startProcessGroup(cmd *exec.Cmd, grace time.Duration) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		pgid := cmd.Process.Pid
+		if grace <= 0 {
+			return syscall.Kill(-pgid, syscall.SIGKILL)
+		}
+		syscall.Kill(-pgid, syscall.SIGTERM)
+		time.AfterFunc(grace, func() {
+			syscall.Kill(-pgid, syscall.SIGKILL)
+		})
+		return nil
+	}
+	cmd.WaitDelay = max(grace, minWaitDelay)
+}
+
+func groupProcesses(pgid int) ([]int, bool) {
+	if syscall.Kill(-pgid, 0) != nil {
+		return nil, false
+	}
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, true
+	}
+	var pids []int
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+		if processGroup(pid) == pgid {
+			pids = append(pids, pid)
+		}
+	}
+	return pids, len(pids) > 0
+}
+
+func processGroup(pid int) int {
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return -1
+	}
+	commEnd := strings.LastIndex(string(stat), ")")
+	if commEnd == -1 {
+		return -1
+	}
+	values := strings.Fields(string(stat)[commEnd+1:])
+	if len(values) < 3 || values[0] == "Z" {
+		return -1
+	}
+	pgrp, err := strconv.Atoi(values[2])
+	if err != nil {
+		return -1
+	}
+	return pgrp
+}
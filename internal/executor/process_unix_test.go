@@ -0,0 +1,148 @@
+//go:build unix
+
+package executor
+
+import (
+	"errors"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellExecutor_TimeoutKillsGrandchildren(t *testing.T) {
+	exec := NewShellExecutor()
+	marker := filepath.Join(t.TempDir(), "survived")
+
+	start := time.Now()
+	_, _, _, err := exec.Execute("(sleep 1; touch "+marker+") & wait", "100ms", nil)
+	elapsed := time.Since(start)
+	time.Sleep(1500 * time.Millisecond)
+
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.Less(t, elapsed, time.Second)
+	assert.NoFileExists(t, marker)
+}
+
+func TestShellExecutor_EscalatesToSigkillAfterGrace(t *testing.T) {
+	exec := &ShellExecutor{KillGrace: 200 * time.Millisecond}
+
+	start := time.Now()
+	_, _, _, err := exec.Execute("trap '' TERM; sleep 5", "100ms", nil)
+	elapsed := time.Since(start)
+
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.GreaterOrEqual(t, elapsed, 300*time.Millisecond)
+	assert.Less(t, elapsed, 2*time.Second)
+}
+
+func TestShellExecutor_ReportsLeakedProcesses(t *testing.T) {
+	exec := NewShellExecutor()
+
+	_, _, exitCode, err := exec.Execute("sleep 2 > /dev/null 2>&1 &", "5s", nil)
+
+	assert.Equal(t, 0, exitCode)
+	var leak *LeakError
+	require.True(t, errors.As(err, &leak))
+	assert.NotEmpty(t, leak.PIDs)
+	for _, pid := range leak.PIDs {
+		syscall.Kill(pid, syscall.SIGKILL)
+	}
+}
+
+func TestShellExecutor_DoesNotWaitForLeakedPipes(t *testing.T) {
+	exec := &ShellExecutor{KillGrace: 200 * time.Millisecond}
+
+	start := time.Now()
+	stdout, _, _, err := exec.Execute("echo started; sleep 3 &", "5s", nil)
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.Equal(t, "started\n", stdout)
+	var leak *LeakError
+	require.True(t, errors.As(err, &leak))
+	syscall.Kill(-leak.PGID, syscall.SIGKILL)
+}
+
+func TestShellExecutor_NoLeakWhenChildrenExit(t *testing.T) {
+	exec := NewShellExecutor()
+
+	_, _, _, err := exec.Execute("sleep 0.1 & wait", "5s", nil)
+
+	assert.NoError(t, err)
+}
+
+func TestShellExecutor_StartStreamsOutputUntilStopped(t *testing.T) {
+	exec := &ShellExecutor{KillGrace: 200 * time.Millisecond}
+	lines := make(chan string, 10)
+
+	process, err := exec.Start("echo ready; sleep 10", nil, func(stream, data string) {
+		lines <- data
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "ready\n", <-lines)
+	select {
+	case <-process.Done():
+		t.Fatal("process exited before Stop")
+	default:
+	}
+	start := time.Now()
+	exitCode := process.Stop()
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NotEqual(t, 0, exitCode)
+}
+
+func TestShellExecutor_StartReportsExitOfShortLivedProcess(t *testing.T) {
+	exec := NewShellExecutor()
+
+	process, err := exec.Start("exit 3", nil, nil)
+	require.NoError(t, err)
+
+	<-process.Done()
+	assert.Equal(t, 3, process.ExitCode())
+	assert.Equal(t, 3, process.Stop())
+}
+
+func TestShellExecutor_StopKillsServiceProcessGroup(t *testing.T) {
+	exec := &ShellExecutor{KillGrace: 200 * time.Millisecond}
+	marker := filepath.Join(t.TempDir(), "survived")
+
+	process, err := exec.Start("(sleep 1; touch "+marker+") & trap '' TERM; wait", nil, nil)
+	require.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	process.Stop()
+	time.Sleep(1200 * time.Millisecond)
+
+	assert.NoFileExists(t, marker)
+}
+
+func TestShellExecutor_InterruptStopsRunningCommands(t *testing.T) {
+	exec := &ShellExecutor{KillGrace: 200 * time.Millisecond}
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		exec.Interrupt()
+	}()
+
+	start := time.Now()
+	_, _, exitCode, err := exec.Execute("sleep 5", "10s", nil)
+
+	assert.True(t, errors.Is(err, ErrInterrupted))
+	assert.Equal(t, -1, exitCode)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestShellExecutor_CommandsAfterInterruptRunNormally(t *testing.T) {
+	exec := NewShellExecutor()
+	exec.Interrupt()
+
+	stdout, _, exitCode, err := exec.Execute("echo teardown", "5s", nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, exitCode)
+	assert.Equal(t, "teardown\n", stdout)
+}
@@ -9,6 +9,7 @@ type WritableFS interface {
 	WriteFile(path string, data []byte) error
 	AppendFile(path string, data []byte) error
 	ReadFile(path string) ([]byte, error)
+	RemoveAll(path string) error
 }
 
 type OSWritableFS struct {
@@ -44,3 +45,7 @@ func (fs *OSWritableFS) AppendFile(path string, data []byte) error {
 func (fs *OSWritableFS) ReadFile(path string) ([]byte, error) {
 	return os.ReadFile(filepath.Join(fs.BaseDir, path))
 }
+
+func (fs *OSWritableFS) RemoveAll(path string) error {
+	return os.RemoveAll(filepath.Join(fs.BaseDir, path))
+}
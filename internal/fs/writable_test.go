@@ -21,6 +21,20 @@ func TestOSWritableFS_WriteFile(t *testing.T) {
 	assert.Equal(t, "hello", string(content))
 }
 
+func TestOSWritableFS_RemoveAll(t *testing.T) {
+	tempDir := t.TempDir()
+	fs := OSWritableFS{BaseDir: tempDir}
+
+	err := fs.WriteFile("run/scenario/stdout", []byte("hello"))
+	require.NoError(t, err)
+
+	err = fs.RemoveAll("run/scenario")
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(tempDir, "run/scenario"))
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestOSWritableFS_AppendFile(t *testing.T) {
 	tempDir := t.TempDir()
 	fs := OSWritableFS{BaseDir: tempDir}
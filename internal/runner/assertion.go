@@ -3,16 +3,28 @@ package runner
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"basanos/internal/assert"
+	"basanos/internal/spec"
 )
 
 type CapturedOutput struct {
-	Stdout   string
-	Stderr   string
-	ExitCode int
+	Stdout    string
+	Stderr    string
+	RawStdout string
+	RawStderr string
+	ExitCode  int
+}
+
+func normalizeCaptured(captured CapturedOutput, rules []spec.NormalizeRule) CapturedOutput {
+	captured.RawStdout = captured.Stdout
+	captured.RawStderr = captured.Stderr
+	captured.Stdout = spec.Normalize(captured.Stdout, rules)
+	captured.Stderr = spec.Normalize(captured.Stderr, rules)
+	return captured
 }
 
 func resolveAssertionArgs(command string, captured CapturedOutput, env map[string]string) (first, second string, err error) {
@@ -26,11 +38,55 @@ func resolveAssertionArgs(command string, captured CapturedOutput, env map[strin
 	}
 
 	first = resolveArg(args[0], captured, env)
+	if isSnapshotExecutable(expanded) {
+		first = snapshotPath(args[0], env)
+	}
 	second = resolveArg(args[1], captured, env)
 
 	return first, second, nil
 }
 
+func isSnapshotExecutable(expandedCommand string) bool {
+	executable, _ := parseCommandArgs(expandedCommand)
+	return filepath.Base(executable) == "assert_snapshot"
+}
+
+func snapshotPath(arg string, env map[string]string) string {
+	if filepath.IsAbs(arg) {
+		return arg
+	}
+	return filepath.Join(env["SPEC_ROOT"], "__snapshots__", arg)
+}
+
+func snapshotFile(command string, env map[string]string) (string, bool) {
+	expanded := os.Expand(command, func(key string) string {
+		return env[key]
+	})
+	_, args := parseCommandArgs(expanded)
+	if !isSnapshotExecutable(expanded) || len(args) == 0 {
+		return "", false
+	}
+	return snapshotPath(args[0], env), true
+}
+
+func readSnapshot(file string) (string, bool) {
+	content, err := os.ReadFile(file)
+	return string(content), err == nil
+}
+
+func snapshotStatus(file, before string, existed bool, exitCode int) string {
+	after, exists := readSnapshot(file)
+	switch {
+	case !existed && exists:
+		return "new"
+	case existed && after != before:
+		return "updated"
+	case existed && exitCode != 0:
+		return "stale"
+	}
+	return ""
+}
+
 func runOutputVar() string {
 	return "RUN_OUTPUT"
 }
@@ -47,6 +103,14 @@ func stderrPath(runOutput string) string {
 	return runOutput + "/stderr"
 }
 
+func rawStdoutPath(runOutput string) string {
+	return runOutput + "/raw/stdout"
+}
+
+func rawStderrPath(runOutput string) string {
+	return runOutput + "/raw/stderr"
+}
+
 func exitCodePath(runOutput string) string {
 	return runOutput + "/exit_code"
 }
@@ -55,16 +119,20 @@ func usesResources(unexpanded_command string, env map[string]string) bool {
 	runOutput := fmt.Sprintf("${%s}", runOutputVar())
 	return strings.Contains(unexpanded_command, exitCodePath(runOutput)) ||
 		strings.Contains(unexpanded_command, stdoutPath(runOutput)) ||
-		strings.Contains(unexpanded_command, stderrPath(runOutput))
+		strings.Contains(unexpanded_command, stderrPath(runOutput)) ||
+		strings.Contains(unexpanded_command, rawStdoutPath(runOutput)) ||
+		strings.Contains(unexpanded_command, rawStderrPath(runOutput))
 }
 
 func resolveArg(arg string, captured CapturedOutput, env map[string]string) string {
 	runOutput := runOutput(env)
 
 	capturedValues := map[string]string{
-		stdoutPath(runOutput):   captured.Stdout,
-		stderrPath(runOutput):   captured.Stderr,
-		exitCodePath(runOutput): strconv.Itoa(captured.ExitCode),
+		stdoutPath(runOutput):    captured.Stdout,
+		stderrPath(runOutput):    captured.Stderr,
+		rawStdoutPath(runOutput): captured.RawStdout,
+		rawStderrPath(runOutput): captured.RawStderr,
+		exitCodePath(runOutput):  strconv.Itoa(captured.ExitCode),
 	}
 
 	if value, ok := capturedValues[arg]; ok {
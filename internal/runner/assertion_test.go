@@ -2,8 +2,11 @@ package runner
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
+	"basanos/internal/spec"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -23,6 +26,20 @@ func TestResolveAssertionArgs_LogicalStdout(t *testing.T) {
 	assert.Equal(t, "hello world", second)
 }
 
+func TestResolveAssertionArgs_RawStdout(t *testing.T) {
+	captured := normalizeCaptured(CapturedOutput{Stdout: "took 152ms"}, []spec.NormalizeRule{{Pattern: "[0-9]+ms", Replace: "<N>ms"}})
+	env := map[string]string{"RUN_OUTPUT": "/path/to/run"}
+
+	_, normalized, err := resolveAssertionArgs("assert_equals expected.txt ${RUN_OUTPUT}/stdout", captured, env)
+	require.NoError(t, err)
+	_, raw, err := resolveAssertionArgs("assert_equals expected.txt ${RUN_OUTPUT}/raw/stdout", captured, env)
+	require.NoError(t, err)
+
+	assert.Equal(t, "took <N>ms", normalized)
+	assert.Equal(t, "took 152ms", raw)
+	assert.True(t, usesResources("assert_equals expected.txt ${RUN_OUTPUT}/raw/stderr", env))
+}
+
 func TestResolveAssertionArgs_LogicalExitCode(t *testing.T) {
 	captured := CapturedOutput{
 		Stdout:   "",
@@ -87,6 +104,44 @@ func TestResolveAssertionArgs_ReadsFileContents(t *testing.T) {
 	assert.Equal(t, "expected content", second)
 }
 
+func TestResolveAssertionArgs_SnapshotKeepsPathUnderSpecRoot(t *testing.T) {
+	captured := CapturedOutput{Stdout: "welcome\n"}
+	env := map[string]string{"RUN_OUTPUT": "/path/to/run", "SPEC_ROOT": "/specs"}
+
+	first, second, err := resolveAssertionArgs("assert_snapshot api/login.snap ${RUN_OUTPUT}/stdout", captured, env)
+
+	require.NoError(t, err)
+	assert.Equal(t, "/specs/__snapshots__/api/login.snap", first)
+	assert.Equal(t, "welcome\n", second)
+}
+
+func TestSnapshotFile_KeepsAbsolutePaths(t *testing.T) {
+	env := map[string]string{"BIN": "/bin", "SPEC_ROOT": "/specs"}
+
+	file, isSnapshot := snapshotFile("${BIN}/assert_snapshot /tmp/login.snap ${RUN_OUTPUT}/stdout", env)
+
+	assert.True(t, isSnapshot)
+	assert.Equal(t, "/tmp/login.snap", file)
+}
+
+func TestSnapshotFile_IgnoresOtherAssertions(t *testing.T) {
+	_, isSnapshot := snapshotFile("assert_equals login.snap ${RUN_OUTPUT}/stdout", map[string]string{})
+
+	assert.False(t, isSnapshot)
+}
+
+func TestSnapshotStatus(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "login.snap")
+
+	assert.Equal(t, "", snapshotStatus(file, "", false, 1))
+
+	require.NoError(t, os.WriteFile(file, []byte("welcome\n"), 0644))
+	assert.Equal(t, "new", snapshotStatus(file, "", false, 0))
+	assert.Equal(t, "", snapshotStatus(file, "welcome\n", true, 0))
+	assert.Equal(t, "stale", snapshotStatus(file, "welcome\n", true, 1))
+	assert.Equal(t, "updated", snapshotStatus(file, "goodbye\n", true, 0))
+}
+
 func TestParseCommandArgs_SimpleArgs(t *testing.T) {
 	executable, args := parseCommandArgs("cmd arg1 arg2")
 
@@ -0,0 +1,140 @@
+package runner
+
+import (
+	"fmt"
+	"strings"
+
+	"basanos/internal/executor"
+	"basanos/internal/spec"
+)
+
+const failedAssertionExitCode = 1
+
+type branchResult struct {
+	label    string
+	output   string
+	exitCode int
+}
+
+func isCompound(assertion spec.Assertion) bool {
+	return assertion.Not || len(assertion.AnyOf) > 0 || len(assertion.AllOf) > 0
+}
+
+func describeAssertion(assertion spec.Assertion) string {
+	description := assertion.Command
+	switch {
+	case len(assertion.AnyOf) > 0:
+		description = "any_of(" + describeBranches(assertion.AnyOf) + ")"
+	case len(assertion.AllOf) > 0:
+		description = "all_of(" + describeBranches(assertion.AllOf) + ")"
+	}
+	if assertion.Not {
+		return "not " + description
+	}
+	return description
+}
+
+func describeBranches(branches []spec.Assertion) string {
+	descriptions := make([]string, len(branches))
+	for i, branch := range branches {
+		descriptions[i] = describeAssertion(branch)
+	}
+	return strings.Join(descriptions, "; ")
+}
+
+func indentOutput(output string) string {
+	output = strings.TrimRight(output, "\n")
+	if output == "" {
+		return ""
+	}
+	return "  " + strings.ReplaceAll(output, "\n", "\n  ") + "\n"
+}
+
+func formatBranches(summary string, branches []branchResult) string {
+	var output strings.Builder
+	output.WriteString(summary + "\n")
+	output.WriteString("──────────────────────────────────\n")
+	for i, branch := range branches {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		output.WriteString(branch.label + "\n")
+		output.WriteString(indentOutput(branch.output))
+	}
+	return output.String()
+}
+
+func negate(description string, inner branchResult) (string, int) {
+	switch inner.exitCode {
+	case 0:
+		return formatBranches("FAIL: expected assertion to fail, but it passed", []branchResult{inner}), failedAssertionExitCode
+	case failedAssertionExitCode:
+		return "PASS: " + description + "\n", 0
+	}
+	summary := fmt.Sprintf("FAIL: negated assertion errored with exit %d", inner.exitCode)
+	return formatBranches(summary, []branchResult{inner}), failedAssertionExitCode
+}
+
+func (runner *Runner) evaluateAssertion(path, phase string, assertion spec.Assertion, env map[string]string, captured CapturedOutput, onOutput executor.OutputHandler) (stdout string, stderr string, exitCode int) {
+	if !isCompound(assertion) {
+		stdout, stderr, exitCode, err := runner.executeAssertion(assertion, env, captured, onOutput)
+		runner.reportLeak(path, phase, err)
+		return stdout, stderr, exitCode
+	}
+
+	var output string
+	switch {
+	case assertion.Not:
+		positive := assertion
+		positive.Not = false
+		stdout, stderr, code := runner.evaluateAssertion(path, phase, positive, env, captured, nil)
+		output, exitCode = negate(describeAssertion(assertion), branchResult{
+			label:    describeAssertion(positive),
+			output:   stdout + stderr,
+			exitCode: code,
+		})
+	case len(assertion.AnyOf) > 0:
+		output, exitCode = runner.evaluateAnyOf(path, phase, assertion, env, captured)
+	default:
+		output, exitCode = runner.evaluateAllOf(path, phase, assertion, env, captured)
+	}
+	if onOutput != nil {
+		onOutput("stdout", output)
+	}
+	return output, "", exitCode
+}
+
+func (runner *Runner) evaluateBranch(path, phase, label string, branch spec.Assertion, timeout string, env map[string]string, captured CapturedOutput) branchResult {
+	if branch.Timeout == "" {
+		branch.Timeout = timeout
+	}
+	stdout, stderr, exitCode := runner.evaluateAssertion(path, phase, branch, env, captured, nil)
+	return branchResult{
+		label:    label + " " + describeAssertion(branch),
+		output:   stdout + stderr,
+		exitCode: exitCode,
+	}
+}
+
+func (runner *Runner) evaluateAnyOf(path, phase string, assertion spec.Assertion, env map[string]string, captured CapturedOutput) (string, int) {
+	var failures []branchResult
+	for index, branch := range assertion.AnyOf {
+		result := runner.evaluateBranch(path, phase, fmt.Sprintf("any_of[%d]", index), branch, assertion.Timeout, env, captured)
+		if result.exitCode == 0 {
+			return "PASS: " + result.label + " passed\n", 0
+		}
+		failures = append(failures, result)
+	}
+	summary := fmt.Sprintf("FAIL: none of %d any_of branches passed", len(failures))
+	return formatBranches(summary, failures), failedAssertionExitCode
+}
+
+func (runner *Runner) evaluateAllOf(path, phase string, assertion spec.Assertion, env map[string]string, captured CapturedOutput) (string, int) {
+	for index, branch := range assertion.AllOf {
+		result := runner.evaluateBranch(path, phase, fmt.Sprintf("all_of[%d]", index), branch, assertion.Timeout, env, captured)
+		if result.exitCode != 0 {
+			return formatBranches("FAIL: "+result.label+" failed", []branchResult{result}), failedAssertionExitCode
+		}
+	}
+	return fmt.Sprintf("PASS: all %d all_of branches passed\n", len(assertion.AllOf)), 0
+}
@@ -2,10 +2,13 @@ package runner
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path"
 	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"basanos/internal/assert"
@@ -30,74 +33,250 @@ type runContext struct {
 	beforeEachHooks []*spec.Hook
 	afterEachHooks  []*spec.Hook
 	onFailure       string
+	parallel        bool
 	env             map[string]string
+	normalize       []spec.NormalizeRule
 	specRoot        string
 	outputRoot      string
 }
 
+type runState struct {
+	mutex        sync.Mutex
+	passed       int
+	failed       int
+	skipped      int
+	flaky        int
+	hookErrors   int
+	interruption string
+	outputSeq    int64
+}
+
+type eventBuffer struct {
+	events []any
+}
+
+func (buffer *eventBuffer) Emit(event any) error {
+	buffer.events = append(buffer.events, event)
+	return nil
+}
+
 type Runner struct {
-	executor executor.Executor
-	sinks    []sinkpkg.Sink
-	passed   int
-	failed   int
-	aborted  bool
-	runID    string
-	Filter   string
+	executor  executor.Executor
+	sinks     []sinkpkg.Sink
+	buffering bool
+	state     *runState
+	slots     chan struct{}
+	sleep     func(time.Duration)
+	now       func() time.Time
+	runID     string
+	Filter    string
+	Jobs      int
+
+	UpdateSnapshots bool
 }
 
 func NewRunner(exec executor.Executor, sinks ...sinkpkg.Sink) *Runner {
 	return &Runner{
 		executor: exec,
 		sinks:    sinks,
+		state:    &runState{},
+		sleep:    time.Sleep,
+		now:      time.Now,
 	}
 }
 
 func (runner *Runner) Passed() int {
-	return runner.passed
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	return runner.state.passed
 }
 
 func (runner *Runner) Failed() int {
-	return runner.failed
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	return runner.state.failed
+}
+
+func (runner *Runner) Skipped() int {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	return runner.state.skipped
+}
+
+func (runner *Runner) Flaky() int {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	return runner.state.flaky
+}
+
+func (runner *Runner) HookErrors() int {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	return runner.state.hookErrors
+}
+
+func (runner *Runner) Interruption() string {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	return runner.state.interruption
+}
+
+func (runner *Runner) Interrupt(signal string) {
+	runner.interrupt("signal", signal)
+	runner.executor.Interrupt()
+}
+
+func (runner *Runner) reset() {
+	runner.state = &runState{}
+	runner.slots = make(chan struct{}, max(runner.Jobs, 1))
+}
+
+func (runner *Runner) record(status string) {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	switch status {
+	case "pass":
+		runner.state.passed++
+	case "flaky":
+		runner.state.passed++
+		runner.state.flaky++
+	default:
+		runner.state.failed++
+	}
+}
+
+func (runner *Runner) recordSkip() {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	runner.state.skipped++
+}
+
+func (runner *Runner) recordHookError() {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	runner.state.hookErrors++
+}
+
+func (runner *Runner) markInterrupted(reason string) bool {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	if runner.state.interruption != "" {
+		return false
+	}
+	runner.state.interruption = reason
+	return true
+}
+
+func (runner *Runner) interrupt(reason, detail string) {
+	if runner.markInterrupted(reason) {
+		runner.emit(eventpkg.NewRunInterruptedEvent(runner.runID, reason, detail, runner.now()))
+	}
+}
+
+func (runner *Runner) isAborted() bool {
+	return runner.Interruption() != ""
 }
 
 func (runner *Runner) emit(event any) {
-	for _, sink := range runner.sinks {
-		sink.Emit(event)
+	runner.emitAll([]any{event})
+}
+
+func (runner *Runner) emitAll(events []any) {
+	runner.state.mutex.Lock()
+	defer runner.state.mutex.Unlock()
+	if !runner.buffering {
+		runner.sequenceOutput(events)
+	}
+	runner.send(events...)
+}
+
+func (runner *Runner) sequenceOutput(events []any) {
+	for _, event := range events {
+		if output, isOutput := event.(*eventpkg.OutputEvent); isOutput {
+			runner.state.outputSeq++
+			output.Seq = runner.state.outputSeq
+		}
+	}
+}
+
+func (runner *Runner) emitOutput(path, phase, stream, data string) {
+	runner.emit(eventpkg.NewOutputEvent(runner.runID, path, phase, 0, stream, data))
+}
+
+func (runner *Runner) send(events ...any) {
+	for _, event := range events {
+		for _, sink := range runner.sinks {
+			sink.Emit(event)
+		}
+	}
+}
+
+func (runner *Runner) buffered() (*Runner, *eventBuffer) {
+	buffer := &eventBuffer{}
+	worker := *runner
+	worker.sinks = []sinkpkg.Sink{buffer}
+	worker.buffering = true
+	return &worker, buffer
+}
+
+func (runner *Runner) outputHandler(path, phase string) executor.OutputHandler {
+	return func(stream, data string) {
+		if data != "" {
+			runner.emitOutput(path, phase, stream, data)
+		}
 	}
 }
 
-func (runner *Runner) emitOutput(stream, data string) {
-	if data != "" {
-		runner.emit(eventpkg.NewOutputEvent(runner.runID, stream, data))
+func (runner *Runner) reportLeak(path, phase string, err error) {
+	var leak *executor.LeakError
+	if errors.As(err, &leak) {
+		runner.emit(eventpkg.NewProcessLeakEvent(runner.runID, path, phase, leak.PGID, leak.PIDs))
 	}
 }
 
-func (runner *Runner) exec(command, timeout string, env map[string]string) (int, bool) {
-	_, _, exitCode, timedOut := runner.execCapture(command, timeout, env)
+func (runner *Runner) exec(path, phase, command, timeout string, env map[string]string) (int, bool) {
+	_, _, exitCode, timedOut := runner.execCapture(path, phase, command, timeout, env)
 	return exitCode, timedOut
 }
 
-func (runner *Runner) execCapture(command, timeout string, env map[string]string) (string, string, int, bool) {
+func (runner *Runner) execCapture(path, phase, command, timeout string, env map[string]string) (string, string, int, bool) {
 	expandedCommand := substituteVars(command, env)
-	stdout, stderr, exitCode, err := runner.executor.Execute(expandedCommand, timeout, env)
-	runner.emitOutput("stdout", stdout)
-	runner.emitOutput("stderr", stderr)
+	stdout, stderr, exitCode, err := runner.executor.ExecuteStreaming(expandedCommand, timeout, env, "", runner.outputHandler(path, phase))
+	runner.reportLeak(path, phase, err)
 	return stdout, stderr, exitCode, errors.Is(err, executor.ErrTimeout)
 }
 
-func (runner *Runner) runHook(path, hookName string, hook *spec.Hook, env map[string]string) {
+func (runner *Runner) runHook(path, hookName string, hook *spec.Hook, env map[string]string) bool {
 	if hook == nil {
-		return
+		return true
 	}
-	runner.emit(eventpkg.NewHookStartEvent(runner.runID, path, "_"+hookName, ""))
-	exitCode, _ := runner.exec(hook.Run, hook.Timeout, env)
-	runner.emit(eventpkg.NewHookEndEvent(runner.runID, path, "_"+hookName, "", exitCode))
+	started := runner.now()
+	runner.emit(eventpkg.NewHookStartEvent(runner.runID, path, "_"+hookName, "", started))
+	exitCode, timedOut := runner.exec(path, "_"+hookName, hook.Run, hook.Timeout, env)
+	if timedOut {
+		runner.emit(eventpkg.NewTimeoutEvent(runner.runID, path, hookName, hook.Timeout))
+	}
+	runner.emit(eventpkg.NewHookEndEvent(runner.runID, path, "_"+hookName, "", exitCode, runner.now().Sub(started)))
+	return exitCode == 0 && !timedOut
 }
 
-func (runner *Runner) runHooks(path, hookName string, hooks []*spec.Hook, env map[string]string) {
+func (runner *Runner) runSetupHooks(path, hookName string, hooks []*spec.Hook, env map[string]string) (started int, passed bool) {
+	for index, hook := range hooks {
+		if !runner.runHook(path, hookName, hook, env) {
+			return index + 1, false
+		}
+	}
+	return len(hooks), true
+}
+
+func (runner *Runner) runTeardownHooks(path, hookName string, hooks []*spec.Hook, env map[string]string) bool {
+	succeeded := true
 	for _, hook := range hooks {
-		runner.runHook(path, hookName, hook, env)
+		if !runner.runHook(path, hookName, hook, env) {
+			succeeded = false
+		}
 	}
+	return succeeded
 }
 
 func reversed(hooks []*spec.Hook) []*spec.Hook {
@@ -114,25 +293,42 @@ func extractExecutable(command string) string {
 	return parts[0]
 }
 
-func (runner *Runner) executeAssertion(assertion spec.Assertion, env map[string]string, captured CapturedOutput) (stdout string, stderr string, exitCode int, err error) {
-	if usesResources(assertion.Command, env) {
+func (runner *Runner) executeAssertion(assertion spec.Assertion, env map[string]string, captured CapturedOutput, onOutput executor.OutputHandler) (stdout string, stderr string, exitCode int, err error) {
+	if runner.UpdateSnapshots {
+		env = mergeEnv(env, map[string]string{"BASANOS_UPDATE_SNAPSHOTS": "1"})
+	}
+	_, isSnapshot := snapshotFile(assertion.Command, env)
+	if usesResources(assertion.Command, env) || isSnapshot {
 		executable := extractExecutable(assertion.Command)
 		first, second, _ := resolveAssertionArgs(assertion.Command, captured, env)
 		protocol := assert.BuildProtocol(first, second)
-		return runner.executor.ExecuteWithStdin(executable, assertion.Timeout, env, protocol)
+		return runner.executor.ExecuteStreaming(executable, assertion.Timeout, env, protocol, onOutput)
 	} else {
-		return runner.executor.Execute(assertion.Command, assertion.Timeout, env)
+		return runner.executor.ExecuteStreaming(assertion.Command, assertion.Timeout, env, "", onOutput)
 	}
 }
 
-func (runner *Runner) runAssertion(path string, assertion spec.Assertion, env map[string]string, captured CapturedOutput, index int) bool {
-	runner.emit(eventpkg.NewAssertionStartEvent(runner.runID, path, index, assertion.Command))
+func (runner *Runner) runAssertion(path string, assertion spec.Assertion, env map[string]string, captured CapturedOutput, rules []spec.NormalizeRule, index int) bool {
+	started := runner.now()
+	runner.emit(eventpkg.NewAssertionStartEvent(runner.runID, path, index, describeAssertion(assertion), started))
+
+	snapshot, isSnapshot := snapshotFile(assertion.Command, env)
+	before, existed := readSnapshot(snapshot)
 
-	stdout, stderr, exitCode, _ := runner.executeAssertion(assertion, env, captured)
+	phase := fmt.Sprintf("_assertions/%d", index)
+	exitCode, attempts, diagnostic := 0, 1, ""
+	if assertion.Within != "" {
+		exitCode, attempts, diagnostic = runner.pollAssertion(path, phase, assertion, env, captured, rules)
+	} else {
+		_, _, exitCode = runner.evaluateAssertion(path, phase, assertion, env, captured, runner.outputHandler(path, phase))
+	}
+	if isSnapshot {
+		if status := snapshotStatus(snapshot, before, existed, exitCode); status != "" {
+			runner.emit(eventpkg.NewSnapshotEvent(runner.runID, path, index, snapshot, status))
+		}
+	}
 
-	runner.emitOutput("stdout", stdout)
-	runner.emitOutput("stderr", stderr)
-	runner.emit(eventpkg.NewAssertionEndEvent(runner.runID, path, index, exitCode))
+	runner.emit(eventpkg.NewAssertionEndEvent(runner.runID, path, index, exitCode, attempts, diagnostic, runner.now().Sub(started)))
 
 	if exitCode != 0 {
 		return false
@@ -140,63 +336,180 @@ func (runner *Runner) runAssertion(path string, assertion spec.Assertion, env ma
 	return true
 }
 
-func (runner *Runner) runAssertions(path string, assertions []spec.Assertion, env map[string]string, captured CapturedOutput) bool {
+const defaultPollInterval = time.Second
+
+func (runner *Runner) probeAssertion(path string, assertion spec.Assertion, env map[string]string, rules []spec.NormalizeRule) CapturedOutput {
+	stdout, stderr, exitCode, err := runner.executor.ExecuteStreaming(substituteVars(assertion.Probe, env), assertion.Timeout, env, "", nil)
+	runner.reportLeak(path, "_probe", err)
+	return normalizeCaptured(CapturedOutput{Stdout: stdout, Stderr: stderr, ExitCode: exitCode}, rules)
+}
+
+func (runner *Runner) pollAssertion(path, phase string, assertion spec.Assertion, env map[string]string, captured CapturedOutput, rules []spec.NormalizeRule) (exitCode int, attempts int, diagnostic string) {
+	deadline := runner.now().Add(durationOr(assertion.Within, 0))
+	interval := durationOr(assertion.Interval, defaultPollInterval)
+	for attempts = 1; ; attempts++ {
+		if assertion.Probe != "" {
+			captured = runner.probeAssertion(path, assertion, env, rules)
+		}
+		stdout, stderr, code := runner.evaluateAssertion(path, phase, assertion, env, captured, nil)
+		exitCode = code
+		if exitCode == 0 || runner.now().Add(interval).After(deadline) || runner.isAborted() {
+			onOutput := runner.outputHandler(path, phase)
+			onOutput("stdout", stdout)
+			onOutput("stderr", stderr)
+			return exitCode, attempts, stdout + stderr
+		}
+		runner.sleep(interval)
+	}
+}
+
+func (runner *Runner) runAssertions(path string, assertions []spec.Assertion, env map[string]string, captured CapturedOutput, rules []spec.NormalizeRule) bool {
 	allPassed := true
 	for index, assertion := range assertions {
-		if !runner.runAssertion(path, assertion, env, captured, index) {
+		if !runner.runAssertion(path, assertion, env, captured, rules, index) {
 			allPassed = false
 		}
 	}
 	return allPassed
 }
 
+func (runner *Runner) runBody(scenarioPath string, scenario spec.Scenario, env map[string]string, rules []spec.NormalizeRule) bool {
+	started := runner.now()
+	runner.emit(eventpkg.NewScenarioRunStartEvent(runner.runID, scenarioPath, started))
+	stdout, stderr, exitCode, timedOut := runner.execCapture(scenarioPath, "_run", scenario.Run.Command, scenario.Run.Timeout, env)
+	if timedOut {
+		runner.emit(eventpkg.NewTimeoutEvent(runner.runID, scenarioPath, "run", scenario.Run.Timeout))
+	}
+	runner.emit(eventpkg.NewScenarioRunEndEvent(runner.runID, scenarioPath, exitCode, runner.now().Sub(started)))
+	captured := normalizeCaptured(CapturedOutput{Stdout: stdout, Stderr: stderr, ExitCode: exitCode}, rules)
+	if len(rules) > 0 {
+		runner.emit(eventpkg.NewOutputNormalizedEvent(runner.runID, scenarioPath, "_run", "stdout", captured.Stdout))
+		runner.emit(eventpkg.NewOutputNormalizedEvent(runner.runID, scenarioPath, "_run", "stderr", captured.Stderr))
+	}
+	if runner.Interruption() == "signal" {
+		return false
+	}
+
+	assertionsPassed := runner.runAssertions(scenarioPath, scenario.Assertions, env, captured, rules)
+	return assertionsPassed && !timedOut
+}
+
+func retryPolicy(scenario spec.Scenario) (int, time.Duration) {
+	retries, retryDelay := scenario.Retries, scenario.RetryDelay
+	if scenario.Run.Retries != 0 {
+		retries = scenario.Run.Retries
+	}
+	if scenario.Run.RetryDelay != "" {
+		retryDelay = scenario.Run.RetryDelay
+	}
+	delay, _ := time.ParseDuration(retryDelay)
+	return retries, delay
+}
+
 func (runner *Runner) runScenario(scenarioPath string, scenario spec.Scenario, ctx runContext) bool {
 	scenarioOutput := path.Join(ctx.outputRoot, scenarioPath)
-	scenarioEnv := mergeEnv(ctx.env, map[string]string{
+	scenarioEnv := mergeEnv(mergeEnv(ctx.env, scenario.Env), map[string]string{
 		"SCENARIO_OUTPUT": scenarioOutput,
 		"RUN_OUTPUT":      path.Join(scenarioOutput, "_run"),
 	})
+	retries, delay := retryPolicy(scenario)
+
+	for attempt := 1; ; attempt++ {
+		started := runner.now()
+		runner.emit(eventpkg.NewScenarioEnterEvent(runner.runID, scenarioPath, scenario.Name, attempt, started))
+		status := runner.runAttempt(scenarioPath, scenario, ctx, scenarioEnv)
+		finished := runner.now()
+		if status != "pass" && attempt <= retries && !runner.isAborted() {
+			runner.emit(eventpkg.NewScenarioExitEvent(runner.runID, scenarioPath, "retry", attempt, finished, finished.Sub(started)))
+			runner.sleep(delay)
+			continue
+		}
+		if status == "pass" && attempt > 1 {
+			status = "flaky"
+		}
+		runner.emit(eventpkg.NewScenarioExitEvent(runner.runID, scenarioPath, status, attempt, finished, finished.Sub(started)))
+		runner.record(status)
+		return status == "pass" || status == "flaky"
+	}
+}
 
-	runner.emit(eventpkg.NewScenarioEnterEvent(runner.runID, scenarioPath, scenario.Name, time.Now()))
-
-	runner.runHooks(scenarioPath, "before_each", ctx.beforeEachHooks, scenarioEnv)
-	runner.runHook(scenarioPath, "before", scenario.Before, scenarioEnv)
+func (runner *Runner) runAttempt(scenarioPath string, scenario spec.Scenario, ctx runContext, scenarioEnv map[string]string) (status string) {
+	startedLevels, beforeEachPassed := 0, false
+	defer func() {
+		afterPassed := !beforeEachPassed || runner.runHook(scenarioPath, "after", scenario.After, scenarioEnv)
+		afterEachPassed := runner.runTeardownHooks(scenarioPath, "after_each", reversed(ctx.afterEachHooks[:startedLevels]), scenarioEnv)
+		if !afterPassed || !afterEachPassed {
+			status = "error"
+		}
+	}()
 
-	runner.emit(eventpkg.NewScenarioRunStartEvent(runner.runID, scenarioPath))
-	stdout, stderr, exitCode, timedOut := runner.execCapture(scenario.Run.Command, scenario.Run.Timeout, scenarioEnv)
-	if timedOut {
-		runner.emit(eventpkg.NewTimeoutEvent(runner.runID, scenarioPath, "run", scenario.Run.Timeout))
+	status = "error"
+	startedLevels, beforeEachPassed = runner.runSetupHooks(scenarioPath, "before_each", ctx.beforeEachHooks, scenarioEnv)
+	if beforeEachPassed && runner.runHook(scenarioPath, "before", scenario.Before, scenarioEnv) {
+		status = "fail"
+		if runner.runBody(scenarioPath, scenario, scenarioEnv, slices.Concat(ctx.normalize, scenario.Normalize)) {
+			status = "pass"
+		}
 	}
-	runner.emit(eventpkg.NewScenarioRunEndEvent(runner.runID, scenarioPath, exitCode))
+	return status
+}
 
-	captured := CapturedOutput{Stdout: stdout, Stderr: stderr, ExitCode: exitCode}
-	assertionsPassed := runner.runAssertions(scenarioPath, scenario.Assertions, scenarioEnv, captured)
-	passed := assertionsPassed && !timedOut
+func (runner *Runner) skipLeaf(scenarioPath string, scenario spec.Scenario, reason string) {
+	if !runner.matchesFilter(scenarioPath) {
+		reason = "filter"
+	}
+	runner.emit(eventpkg.NewScenarioSkippedEvent(runner.runID, scenarioPath, scenario.Name, reason, runner.now()))
+	runner.recordSkip()
+}
 
-	status := "fail"
-	if passed {
-		status = "pass"
+func (runner *Runner) skipScenarios(basePath string, scenarios []spec.Scenario, reason string) {
+	for _, scenario := range scenarios {
+		scenarioPath := basePath + "/" + scenario.ID
+		if scenario.Run != nil {
+			runner.skipLeaf(scenarioPath, scenario, reason)
+		}
+		runner.skipScenarios(scenarioPath, scenario.Scenarios, reason)
 	}
-	runner.emit(eventpkg.NewScenarioExitEvent(runner.runID, scenarioPath, status, time.Now()))
+}
 
-	if passed {
-		runner.passed++
-	} else {
-		runner.failed++
+func (runner *Runner) skipContents(specTree *tree.SpecTree, reason string) {
+	runner.skipScenarios(specTree.Path, specTree.Context.Scenarios, reason)
+	for _, child := range specTree.Children {
+		runner.skipTree(child, reason)
 	}
+}
 
-	runner.runHook(scenarioPath, "after", scenario.After, scenarioEnv)
-	runner.runHooks(scenarioPath, "after_each", reversed(ctx.afterEachHooks), scenarioEnv)
+func (runner *Runner) skipTree(specTree *tree.SpecTree, reason string) {
+	started := runner.now()
+	runner.emit(eventpkg.NewContextEnterEvent(runner.runID, specTree.Path, specTree.Context.Name, started))
+	runner.skipContents(specTree, reason)
+	runner.emitContextExit(specTree.Path, started)
+}
 
-	return passed
+func (runner *Runner) stopReason(onFailure string) string {
+	switch runner.Interruption() {
+	case "":
+		return onFailure
+	case "abort_run":
+		return "abort_run"
+	default:
+		return "interrupted"
+	}
+}
+
+func resolveOnFailure(declared, inherited string) string {
+	if declared != "" {
+		return declared
+	}
+	return inherited
 }
 
-func (runner *Runner) shouldStopAfterFailure(passed bool, onFailure string) bool {
+func (runner *Runner) shouldStopAfterFailure(path string, passed bool, onFailure string) bool {
 	if passed {
 		return false
 	}
 	if onFailure == "abort_run" {
-		runner.aborted = true
+		runner.interrupt("abort_run", path)
 		return true
 	}
 	return onFailure == "skip_children"
@@ -206,36 +519,90 @@ func (runner *Runner) matchesFilter(scenarioPath string) bool {
 	if runner.Filter == "" {
 		return true
 	}
-	matched, err := path.Match(runner.Filter, scenarioPath)
-	if err != nil {
-		return scenarioPath == runner.Filter
+	if scenarioPath == runner.Filter {
+		return true
 	}
+	matched, _ := path.Match(runner.Filter, scenarioPath)
 	return matched
 }
 
-func (runner *Runner) executeLeaf(path string, scenario spec.Scenario, ctx runContext) bool {
+func (runner *Runner) executeLeaf(path string, scenario spec.Scenario, ctx runContext) (stopped bool, onFailure string) {
 	if scenario.Run == nil {
-		return false
+		return false, ""
 	}
 	if !runner.matchesFilter(path) {
-		return false
+		runner.skipLeaf(path, scenario, "filter")
+		return false, ""
 	}
+	onFailure = resolveOnFailure(scenario.OnFailure, ctx.onFailure)
 	passed := runner.runScenario(path, scenario, ctx)
-	return runner.shouldStopAfterFailure(passed, ctx.onFailure)
+	return runner.shouldStopAfterFailure(path, passed, onFailure), onFailure
 }
 
-func (runner *Runner) runScenarios(basePath string, scenarios []spec.Scenario, ctx runContext) {
-	for _, scenario := range scenarios {
-		if runner.aborted {
-			return
+func (runner *Runner) runScenarios(basePath string, scenarios []spec.Scenario, ctx runContext) string {
+	if ctx.parallel && runner.Jobs > 1 {
+		return runner.runScenariosParallel(basePath, scenarios, ctx)
+	}
+	for index, scenario := range scenarios {
+		if runner.isAborted() {
+			reason := runner.stopReason(ctx.onFailure)
+			runner.skipScenarios(basePath, scenarios[index:], reason)
+			return reason
 		}
 		path := basePath + "/" + scenario.ID
 
-		if runner.executeLeaf(path, scenario, ctx) {
-			return
+		if stopped, onFailure := runner.executeLeaf(path, scenario, ctx); stopped {
+			reason := runner.stopReason(onFailure)
+			runner.skipScenarios(basePath, scenarios[index+1:], reason)
+			return reason
 		}
 		runner.runChildScenarios(path, scenario, ctx)
 	}
+	return ""
+}
+
+func (runner *Runner) runScenariosParallel(basePath string, scenarios []spec.Scenario, ctx runContext) string {
+	var workers sync.WaitGroup
+	var stopped atomic.Value
+	panics := make(chan any, len(scenarios))
+	for index, scenario := range scenarios {
+		if runner.isAborted() {
+			stopped.CompareAndSwap(nil, runner.stopReason(ctx.onFailure))
+		}
+		if reason, isStopped := stopped.Load().(string); isStopped {
+			runner.skipScenarios(basePath, scenarios[index:], reason)
+			break
+		}
+		path := basePath + "/" + scenario.ID
+		if scenario.Run == nil {
+			runner.runChildScenarios(path, scenario, ctx)
+			continue
+		}
+		runner.slots <- struct{}{}
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			defer func() { <-runner.slots }()
+			worker, buffer := runner.buffered()
+			defer func() {
+				runner.emitAll(buffer.events)
+				if recovered := recover(); recovered != nil {
+					panics <- recovered
+				}
+			}()
+			if isStopped, onFailure := worker.executeLeaf(path, scenario, ctx); isStopped {
+				stopped.CompareAndSwap(nil, runner.stopReason(onFailure))
+			}
+		}()
+	}
+	workers.Wait()
+	select {
+	case recovered := <-panics:
+		panic(recovered)
+	default:
+	}
+	reason, _ := stopped.Load().(string)
+	return reason
 }
 
 func mergeEnv(parent, child map[string]string) map[string]string {
@@ -256,8 +623,10 @@ func (runner *Runner) runChildScenarios(path string, scenario spec.Scenario, ctx
 	childCtx := runContext{
 		beforeEachHooks: append(ctx.beforeEachHooks, scenario.BeforeEach),
 		afterEachHooks:  append(ctx.afterEachHooks, scenario.AfterEach),
-		onFailure:       ctx.onFailure,
+		onFailure:       resolveOnFailure(scenario.OnFailure, ctx.onFailure),
+		parallel:        scenario.Parallel,
 		env:             mergeEnv(ctx.env, scenario.Env),
+		normalize:       slices.Concat(ctx.normalize, scenario.Normalize),
 		specRoot:        ctx.specRoot,
 		outputRoot:      ctx.outputRoot,
 	}
@@ -268,7 +637,8 @@ func (runner *Runner) runTree(specTree *tree.SpecTree, ctx runContext, parentEnv
 	specRoot := ctx.specRoot
 	outputRoot := ctx.outputRoot
 
-	if runner.aborted {
+	if runner.isAborted() {
+		runner.skipTree(specTree, runner.stopReason(ctx.onFailure))
 		return nil
 	}
 
@@ -278,30 +648,67 @@ func (runner *Runner) runTree(specTree *tree.SpecTree, ctx runContext, parentEnv
 		"CONTEXT_OUTPUT": contextOutput,
 	}))
 
-	runner.emit(eventpkg.NewContextEnterEvent(runner.runID, specTree.Path, specTree.Context.Name, time.Now()))
+	started := runner.now()
+	runner.emit(eventpkg.NewContextEnterEvent(runner.runID, specTree.Path, specTree.Context.Name, started))
 
-	runner.runHook(specTree.Path, "before", specTree.Context.Before, env)
+	services, env, servicesReady := runner.startServices(specTree.Path, specTree.Context.Services, env)
+	defer runner.exitContext(specTree, env, services, started, servicesReady)
+	beforePassed := servicesReady && runner.runHook(specTree.Path, "before", specTree.Context.Before, env)
 
 	new_ctx := runContext{
 		runID:           runner.runID,
 		beforeEachHooks: append(ctx.beforeEachHooks, specTree.Context.BeforeEach),
 		afterEachHooks:  append(ctx.afterEachHooks, specTree.Context.AfterEach),
-		onFailure:       specTree.Context.OnFailure,
+		onFailure:       resolveOnFailure(specTree.Context.OnFailure, ctx.onFailure),
+		parallel:        specTree.Context.Parallel,
 		env:             env,
+		normalize:       slices.Concat(ctx.normalize, specTree.Context.Normalize),
 		specRoot:        specRoot,
 		outputRoot:      outputRoot,
 	}
-	runner.runScenarios(specTree.Path, specTree.Context.Scenarios, new_ctx)
-
-	for _, child := range specTree.Children {
-		runner.runTree(child, new_ctx, env)
+	if beforePassed {
+		stopped := runner.runScenarios(specTree.Path, specTree.Context.Scenarios, new_ctx)
+		for _, child := range specTree.Children {
+			if stopped != "" {
+				runner.skipTree(child, stopped)
+				continue
+			}
+			runner.runTree(child, new_ctx, env)
+		}
+	} else if runner.isAborted() {
+		runner.skipContents(specTree, runner.stopReason(new_ctx.onFailure))
+	} else if servicesReady {
+		runner.recordHookError()
+		runner.skipContents(specTree, "hook_failure")
+	} else {
+		runner.recordHookError()
+		runner.skipContents(specTree, "service_failure")
 	}
 
-	runner.runHook(specTree.Path, "after", specTree.Context.After, env)
+	return nil
+}
 
-	runner.emit(eventpkg.NewContextExitEvent(runner.runID, specTree.Path, time.Now()))
+func (runner *Runner) exitContext(specTree *tree.SpecTree, env map[string]string, services []runningService, started time.Time, beforeStarted bool) {
+	if beforeStarted && !runner.runHook(specTree.Path, "after", specTree.Context.After, env) {
+		runner.recordHookError()
+	}
+	runner.stopServices(specTree.Path, services)
+	runner.emitContextExit(specTree.Path, started)
+}
 
-	return nil
+func (runner *Runner) emitContextExit(path string, started time.Time) {
+	finished := runner.now()
+	runner.emit(eventpkg.NewContextExitEvent(runner.runID, path, finished, finished.Sub(started)))
+}
+
+func (runner *Runner) runRoot(specTree *tree.SpecTree, ctx runContext) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			runner.interrupt("panic", fmt.Sprint(recovered))
+			err = fmt.Errorf("runner panicked: %v", recovered)
+		}
+	}()
+	return runner.runTree(specTree, ctx, nil)
 }
 
 func initialContext(specRoot string, outputRoot string) runContext {
@@ -314,25 +721,29 @@ func initialContext(specRoot string, outputRoot string) runContext {
 }
 
 func (runner *Runner) Run(specTree *tree.SpecTree, absSpecRootPath string) error {
-	return runner.runTree(specTree, initialContext(absSpecRootPath, ""), nil)
+	runner.reset()
+	return runner.runRoot(specTree, initialContext(absSpecRootPath, ""))
 }
 
 func (runner *Runner) RunWithID(runID string, specTree *tree.SpecTree, absSpecRootPath string) error {
 	runner.runID = runID
-	runner.emit(eventpkg.NewRunStartEvent(runID, time.Now()))
-	runner.passed = 0
-	runner.failed = 0
-	runner.aborted = false
+	runner.reset()
+	started := runner.now()
+	runner.emit(eventpkg.NewRunStartEvent(runID, started))
 
 	outputRoot := "runs/" + runID
-	err := runner.runTree(specTree, initialContext(absSpecRootPath, outputRoot), nil)
+	err := runner.runRoot(specTree, initialContext(absSpecRootPath, outputRoot))
 
 	status := "pass"
-	if runner.failed > 0 {
+	if runner.Failed() > 0 || runner.HookErrors() > 0 {
 		status = "fail"
 	}
+	if interruption := runner.Interruption(); interruption == "signal" || interruption == "panic" {
+		status = "interrupted"
+	}
 
-	runner.emit(eventpkg.NewRunEndEvent(runID, status, runner.passed, runner.failed, time.Now()))
+	finished := runner.now()
+	runner.emit(eventpkg.NewRunEndEvent(runID, status, runner.Passed(), runner.Failed(), runner.Skipped(), runner.Flaky(), finished, finished.Sub(started)))
 
 	return err
 }
@@ -1,9 +1,17 @@
 package runner
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	assertpkg "basanos/internal/assert"
 	"basanos/internal/event"
+	"basanos/internal/executor"
 	"basanos/internal/spec"
 	fakeexec "basanos/internal/testutil/executor"
 	"basanos/internal/tree"
@@ -167,6 +175,15 @@ func findEvents[T any](events []any) []T {
 	return result
 }
 
+func indexOfEvent(events []any, target any) int {
+	for index, event := range events {
+		if event == target {
+			return index
+		}
+	}
+	return -1
+}
+
 func TestRunner_ExecutesScenarioRunCommand(t *testing.T) {
 	specTree := withScenarioCommand(newSpecTree("basic"), "curl http://localhost/", "30s")
 
@@ -255,6 +272,100 @@ func TestRunner_EmitsBothStdoutAndStderrOutputEvents(t *testing.T) {
 	assert.Len(t, events, 2)
 }
 
+func TestRunner_OutputEventsAreTaggedWithPathAndPhase(t *testing.T) {
+	specTree := withBeforeHook(newSpecTree("basic"), "setup.sh")
+	specTree.Context.Scenarios[0].Assertions = []spec.Assertion{{Command: "check_output", Timeout: "1s"}}
+
+	_, sink := runSpecWithOutput(t, specTree, "hello\n", "")
+
+	events := findEvents[*event.OutputEvent](sink.Events)
+	require.Len(t, events, 3)
+	assert.Equal(t, "basic", events[0].Path)
+	assert.Equal(t, "_before", events[0].Phase)
+	assert.Equal(t, "basic/scenario", events[1].Path)
+	assert.Equal(t, "_run", events[1].Phase)
+	assert.Equal(t, "basic/scenario", events[2].Path)
+	assert.Equal(t, "_assertions/0", events[2].Phase)
+}
+
+func TestRunner_OutputEventsHaveIncreasingSequenceNumbers(t *testing.T) {
+	specTree := withBeforeHook(withTwoScenarios(newSpecTree("basic")), "setup.sh")
+
+	_, sink := runSpecWithOutput(t, specTree, "hello\n", "oops\n")
+
+	events := findEvents[*event.OutputEvent](sink.Events)
+	require.Len(t, events, 6)
+	for index, output := range events {
+		assert.Equal(t, int64(index+1), output.Seq)
+	}
+}
+
+func TestRunner_ParallelOutputSequenceFollowsEmissionOrder(t *testing.T) {
+	specTree := withParallelScenarios(newSpecTree("basic"), 4)
+	executor := &fakeexec.FakeExecutor{Stdout: "out\n"}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	runner.Jobs = 4
+
+	require.NoError(t, runner.Run(specTree, "/"+specTree.Path))
+
+	seqs := []int64{}
+	for _, output := range findEvents[*event.OutputEvent](sink.Events) {
+		seqs = append(seqs, output.Seq)
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4}, seqs)
+}
+
+func TestRunner_EmitsOutputChunksIncrementally(t *testing.T) {
+	specTree := newSpecTree("basic")
+	specTree.Context.Scenarios[0].Assertions = []spec.Assertion{
+		{Command: "assert_contains one_two ${RUN_OUTPUT}/stdout", Timeout: "1s"},
+	}
+	executor := &fakeexec.FakeExecutor{StdoutChunks: []string{"one_", "two"}}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+
+	require.NoError(t, runner.Run(specTree, "/"+specTree.Path))
+
+	events := findEvents[*event.OutputEvent](sink.Events)
+	require.GreaterOrEqual(t, len(events), 2)
+	assert.Equal(t, "one_", events[0].Data)
+	assert.Equal(t, "two", events[1].Data)
+	assert.Contains(t, executor.StdinReceived, "one_two")
+}
+
+func TestRunner_EmitsProcessLeakEventForLeftoverProcesses(t *testing.T) {
+	specTree := withBeforeHook(newSpecTree("basic"), "start_server &")
+	executor := &fakeexec.FakeExecutor{LeakedProcesses: map[string][]int{"start_server &": {4242, 4243}}}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+
+	require.NoError(t, runner.Run(specTree, "/"+specTree.Path))
+
+	events := findEvents[*event.ProcessLeakEvent](sink.Events)
+	require.Len(t, events, 1)
+	assert.Equal(t, "basic", events[0].Path)
+	assert.Equal(t, "_before", events[0].Phase)
+	assert.Equal(t, 4242, events[0].PGID)
+	assert.Equal(t, []int{4242, 4243}, events[0].PIDs)
+	assert.Equal(t, 1, runner.Passed())
+}
+
+func TestRunner_EmitsProcessLeakEventForAssertions(t *testing.T) {
+	specTree := newSpecTree("basic")
+	specTree.Context.Scenarios[0].Assertions = []spec.Assertion{{Command: "check &", Timeout: "1s"}}
+	executor := &fakeexec.FakeExecutor{LeakedProcesses: map[string][]int{"check &": {99}}}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+
+	require.NoError(t, runner.Run(specTree, "/"+specTree.Path))
+
+	events := findEvents[*event.ProcessLeakEvent](sink.Events)
+	require.Len(t, events, 1)
+	assert.Equal(t, "basic/scenario", events[0].Path)
+	assert.Equal(t, "_assertions/0", events[0].Phase)
+}
+
 func TestRunner_BeforeHook_ExecutesBeforeScenario(t *testing.T) {
 	specTree := withBeforeHook(newSpecTree("basic"), "setup.sh")
 
@@ -761,6 +872,18 @@ func TestRunner_MergesScenarioEnvWithContext(t *testing.T) {
 	assert.Equal(t, "true", executor.Commands[0].Env["DEBUG"])
 }
 
+func TestRunner_LeafEnvOverridesContextEnv(t *testing.T) {
+	specTree := withEnv(newSpecTree("basic"), map[string]string{"ROLE": "guest"})
+	specTree.Context.Scenarios[0].Env = map[string]string{"ROLE": "admin"}
+	specTree.Context.Scenarios[0].Run.Command = "login ${ROLE}"
+
+	executor, _ := runSpec(t, specTree)
+
+	require.Len(t, executor.Commands, 1)
+	assert.Equal(t, "login admin", executor.Commands[0].Command)
+	assert.Equal(t, "admin", executor.Commands[0].Env["ROLE"])
+}
+
 func TestRunner_SubstitutesEnvVarsInCommand(t *testing.T) {
 	specTree := withEnv(newSpecTree("basic"), map[string]string{"MY_VAR": "hello"})
 	specTree.Context.Scenarios[0].Run.Command = "echo ${MY_VAR}"
@@ -917,6 +1040,26 @@ func TestRunner_FilterByExactPath(t *testing.T) {
 	assert.Equal(t, "login_cmd", fakeExecutor.Commands[0].Command)
 }
 
+func TestRunner_FilterByExactMatrixPath(t *testing.T) {
+	specTree := &tree.SpecTree{
+		Path: "spec",
+		Context: &spec.Context{
+			Name: "spec",
+			Scenarios: []spec.Scenario{
+				{ID: "login[admin]", Name: "Login [admin]", Run: &spec.RunBlock{Command: "login_admin", Timeout: "5s"}},
+				{ID: "login[guest]", Name: "Login [guest]", Run: &spec.RunBlock{Command: "login_guest", Timeout: "5s"}},
+			},
+		},
+	}
+	fakeExecutor := &fakeexec.FakeExecutor{}
+	runner := NewRunner(fakeExecutor, &SpySink{})
+	runner.Filter = "spec/login[admin]"
+
+	runner.Run(specTree, absSpecPath(specTree))
+
+	assert.Equal(t, []string{"login_admin"}, executedCommands(fakeExecutor))
+}
+
 func TestRunner_FilterByGlobPattern(t *testing.T) {
 	specTree := &tree.SpecTree{
 		Path: "spec",
@@ -1053,3 +1196,1334 @@ func TestRunner_Assertions_NoProtocolPipingIfNoResources(t *testing.T) {
 	assert.Equal(t, "assert_equals 0 1", assertionCmd.Command)
 	assert.Equal(t, "", fakeExecutor.StdinReceived)
 }
+
+func TestRunner_SnapshotAssertion_PipesSnapshotPathAndReportsStale(t *testing.T) {
+	specRoot := t.TempDir()
+	snapshot := filepath.Join(specRoot, "__snapshots__", "login.snap")
+	require.NoError(t, os.MkdirAll(filepath.Dir(snapshot), 0755))
+	require.NoError(t, os.WriteFile(snapshot, []byte("welcome\n"), 0644))
+	specTree := newSpecTree("basic")
+	specTree.Context.Scenarios[0].Assertions = []spec.Assertion{
+		{Command: "assert_snapshot login.snap ${RUN_OUTPUT}/stdout", Timeout: "1s"},
+	}
+	fakeExecutor := &fakeexec.FakeExecutor{
+		Stdout:    "goodbye\n",
+		ExitCodes: map[string]int{"assert_snapshot": 1},
+	}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, specRoot)
+
+	assert.Equal(t, assertpkg.BuildProtocol(snapshot, "goodbye\n"), fakeExecutor.StdinReceived)
+	assert.NotContains(t, fakeExecutor.Commands[1].Env, "BASANOS_UPDATE_SNAPSHOTS")
+	snapshots := findEvents[*event.SnapshotEvent](sink.Events)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "basic/scenario", snapshots[0].Path)
+	assert.Equal(t, 0, snapshots[0].Index)
+	assert.Equal(t, snapshot, snapshots[0].File)
+	assert.Equal(t, "stale", snapshots[0].Status)
+}
+
+func TestRunner_UpdateSnapshots_PassesUpdateModeToAssertions(t *testing.T) {
+	specTree := newSpecTree("basic")
+	specTree.Context.Scenarios[0].Assertions = []spec.Assertion{
+		{Command: "assert_snapshot login.snap ${RUN_OUTPUT}/stdout", Timeout: "1s"},
+	}
+	fakeExecutor := &fakeexec.FakeExecutor{}
+	runner := NewRunner(fakeExecutor, &SpySink{})
+	runner.UpdateSnapshots = true
+
+	runner.RunWithID("test-run", specTree, t.TempDir())
+
+	require.Len(t, fakeExecutor.Commands, 2)
+	assert.Equal(t, "1", fakeExecutor.Commands[1].Env["BASANOS_UPDATE_SNAPSHOTS"])
+}
+
+func TestRunner_Normalize_AppliesInheritedRulesBeforeAssertions(t *testing.T) {
+	specTree := newSpecTree("basic")
+	specTree.Context.Normalize = []spec.NormalizeRule{{Preset: "timestamps"}}
+	specTree.Context.Scenarios[0].Normalize = []spec.NormalizeRule{{Pattern: "session=[0-9a-f]+", Replace: "session=<ID>"}}
+	specTree.Context.Scenarios[0].Assertions = []spec.Assertion{
+		{Command: "assert_equals expected.txt ${RUN_OUTPUT}/stdout", Timeout: "1s"},
+	}
+	fakeExecutor := &fakeexec.FakeExecutor{Stdout: "at 2026-01-15T14:30:22Z session=9f86d081\n"}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Equal(t, assertpkg.BuildProtocol("expected.txt", "at <TIMESTAMP> session=<ID>\n"), fakeExecutor.StdinReceived)
+	normalized := findEvents[*event.OutputNormalizedEvent](sink.Events)
+	require.Len(t, normalized, 2)
+	assert.Equal(t, "basic/scenario", normalized[0].Path)
+	assert.Equal(t, "_run", normalized[0].Phase)
+	assert.Equal(t, "stdout", normalized[0].Stream)
+	assert.Equal(t, "at <TIMESTAMP> session=<ID>\n", normalized[0].Data)
+	assert.Equal(t, "stderr", normalized[1].Stream)
+}
+
+func TestRunner_Normalize_RawOutputStaysAvailable(t *testing.T) {
+	specTree := newSpecTree("basic")
+	specTree.Context.Normalize = []spec.NormalizeRule{{Preset: "uuids"}}
+	specTree.Context.Scenarios[0].Assertions = []spec.Assertion{
+		{Command: "assert_contains expected.txt ${RUN_OUTPUT}/raw/stdout", Timeout: "1s"},
+	}
+	fakeExecutor := &fakeexec.FakeExecutor{Stdout: "id=3f2504e0-4f89-11d3-9a0c-0305e82c3301\n"}
+	runner := NewRunner(fakeExecutor, &SpySink{})
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Equal(t, assertpkg.BuildProtocol("expected.txt", "id=3f2504e0-4f89-11d3-9a0c-0305e82c3301\n"), fakeExecutor.StdinReceived)
+}
+
+func TestRunner_WithoutNormalizeRules_EmitsNoNormalizedOutput(t *testing.T) {
+	specTree := newSpecTree("basic")
+	fakeExecutor := &fakeexec.FakeExecutor{Stdout: "at 2026-01-15T14:30:22Z\n"}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Empty(t, findEvents[*event.OutputNormalizedEvent](sink.Events))
+}
+
+type pollingExecutor struct {
+	fakeexec.FakeExecutor
+	exitCodes []int
+	probes    []string
+	stdins    []string
+}
+
+func (polling *pollingExecutor) ExecuteStreaming(command string, timeout string, env map[string]string, stdin string, onOutput executor.OutputHandler) (string, string, int, error) {
+	stdout, stderr, exitCode, err := polling.FakeExecutor.ExecuteStreaming(command, timeout, env, stdin, onOutput)
+	if stdin != "" {
+		polling.stdins = append(polling.stdins, stdin)
+	}
+	if command == "curl -s localhost/status" && len(polling.probes) > 0 {
+		stdout, polling.probes = polling.probes[0], polling.probes[1:]
+	}
+	if strings.HasPrefix(command, "assert_") && len(polling.exitCodes) > 0 {
+		exitCode, polling.exitCodes = polling.exitCodes[0], polling.exitCodes[1:]
+		stdout = fmt.Sprintf("attempt exited %d\n", exitCode)
+	}
+	return stdout, stderr, exitCode, err
+}
+
+func pollingSpec(assertion spec.Assertion) *tree.SpecTree {
+	specTree := newSpecTree("jobs")
+	specTree.Context.Scenarios[0].Assertions = []spec.Assertion{assertion}
+	return specTree
+}
+
+func TestRunner_PolledAssertion_RetriesUntilItPasses(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Command: "assert_equals 0 ${RUN_OUTPUT}/exit_code", Within: "10s", Interval: "2s"})
+	pollingExec := &pollingExecutor{exitCodes: []int{1, 1, 0}}
+	sink := &SpySink{}
+	runner := NewRunner(pollingExec, sink)
+	var delays []time.Duration
+	runner.sleep = func(delay time.Duration) { delays = append(delays, delay) }
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, delays)
+	ends := findEvents[*event.AssertionEndEvent](sink.Events)
+	require.Len(t, ends, 1)
+	assert.Equal(t, 0, ends[0].ExitCode)
+	assert.Equal(t, 3, ends[0].Attempts)
+	assert.Equal(t, "attempt exited 0\n", ends[0].Diagnostic)
+	assert.Equal(t, 1, runner.Passed())
+}
+
+func TestRunner_PolledAssertion_GivesUpAtDeadlineWithFinalDiagnostic(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Command: "assert_equals 0 ${RUN_OUTPUT}/exit_code", Within: "3s", Interval: "1s"})
+	pollingExec := &pollingExecutor{exitCodes: []int{1, 2, 3, 4, 5, 6}}
+	sink := &SpySink{}
+	runner := NewRunner(pollingExec, sink)
+	clock := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runner.now = func() time.Time { return clock }
+	runner.sleep = func(delay time.Duration) { clock = clock.Add(delay) }
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	ends := findEvents[*event.AssertionEndEvent](sink.Events)
+	require.Len(t, ends, 1)
+	assert.Equal(t, 4, ends[0].ExitCode)
+	assert.Equal(t, 4, ends[0].Attempts)
+	assert.Equal(t, "attempt exited 4\n", ends[0].Diagnostic)
+	var assertionOutput []string
+	for _, output := range findEvents[*event.OutputEvent](sink.Events) {
+		if output.Phase == "_assertions/0" {
+			assertionOutput = append(assertionOutput, output.Data)
+		}
+	}
+	assert.Equal(t, []string{"attempt exited 4\n"}, assertionOutput)
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func TestRunner_PolledAssertion_ProbeRecapturesOutput(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{
+		Command:  "assert_contains done ${RUN_OUTPUT}/stdout",
+		Within:   "10s",
+		Interval: "1s",
+		Probe:    "curl -s localhost/status",
+	})
+	pollingExec := &pollingExecutor{exitCodes: []int{1, 0}, probes: []string{"pending\n", "done\n"}}
+	runner := NewRunner(pollingExec, &SpySink{})
+	runner.sleep = func(time.Duration) {}
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	var commands []string
+	for _, command := range pollingExec.Commands {
+		commands = append(commands, command.Command)
+	}
+	assert.Equal(t, []string{"test_command", "curl -s localhost/status", "assert_contains", "curl -s localhost/status", "assert_contains"}, commands)
+	assert.Equal(t, []string{
+		assertpkg.BuildProtocol("done", "pending\n"),
+		assertpkg.BuildProtocol("done", "done\n"),
+	}, pollingExec.stdins)
+	assert.Equal(t, 1, runner.Passed())
+}
+
+func TestRunner_Assertion_WithoutWithinRunsOnce(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Command: "assert_equals 0 ${RUN_OUTPUT}/exit_code"})
+	pollingExec := &pollingExecutor{exitCodes: []int{1, 0}}
+	sink := &SpySink{}
+	runner := NewRunner(pollingExec, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	ends := findEvents[*event.AssertionEndEvent](sink.Events)
+	require.Len(t, ends, 1)
+	assert.Equal(t, 1, ends[0].Attempts)
+	assert.Empty(t, ends[0].Diagnostic)
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func phaseOutput(events []any, phase string) string {
+	var output strings.Builder
+	for _, emitted := range findEvents[*event.OutputEvent](events) {
+		if emitted.Phase == phase {
+			output.WriteString(emitted.Data)
+		}
+	}
+	return output.String()
+}
+
+func TestRunner_NegatedAssertion_PassesWhenInnerAssertionFails(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Command: "assert_contains error out", Not: true})
+	fakeExecutor := &fakeexec.FakeExecutor{Stdout: "FAIL: substring not found\n", ExitCodes: map[string]int{"assert_contains error out": 1}}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	starts := findEvents[*event.AssertionStartEvent](sink.Events)
+	require.Len(t, starts, 1)
+	assert.Equal(t, "not assert_contains error out", starts[0].Command)
+	assert.Equal(t, "PASS: not assert_contains error out\n", phaseOutput(sink.Events, "_assertions/0"))
+	assert.Equal(t, 0, findEvents[*event.AssertionEndEvent](sink.Events)[0].ExitCode)
+	assert.Equal(t, 1, runner.Passed())
+}
+
+func TestRunner_NegatedAssertion_FailsWithInnerOutputWhenInnerAssertionPasses(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Command: "assert_contains welcome ${RUN_OUTPUT}/stdout", Not: true})
+	fakeExecutor := &fakeexec.FakeExecutor{Stdout: "PASS: substring found\n"}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Equal(t, "assert_contains", fakeExecutor.Commands[1].Command)
+	assert.Contains(t, fakeExecutor.StdinReceived, "basanos:1\n")
+	assert.Equal(t, "FAIL: expected assertion to fail, but it passed\n"+
+		"──────────────────────────────────\n"+
+		"assert_contains welcome ${RUN_OUTPUT}/stdout\n"+
+		"  PASS: substring found\n", phaseOutput(sink.Events, "_assertions/0"))
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func TestRunner_NegatedAssertion_TreatsOtherExitCodesAsErrors(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Command: "assert_missing a b", Not: true})
+	fakeExecutor := &fakeexec.FakeExecutor{ExitCodes: map[string]int{"assert_missing a b": 127}}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Contains(t, phaseOutput(sink.Events, "_assertions/0"), "FAIL: negated assertion errored with exit 127\n")
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func TestRunner_NegatedAssertion_MalformedJSONPathIsAnError(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Command: "assert_json_path '$.items[x]' ${RUN_OUTPUT}/stdout", Not: true})
+	fakeExecutor := &fakeexec.FakeExecutor{
+		Stdout:    "FAIL: invalid JSONPath query: invalid index [x]\n",
+		ExitCodes: map[string]int{"assert_json_path": 2},
+	}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	output := phaseOutput(sink.Events, "_assertions/0")
+	assert.Contains(t, output, "FAIL: negated assertion errored with exit 2\n")
+	assert.Contains(t, output, "  FAIL: invalid JSONPath query: invalid index [x]\n")
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func TestRunner_AnyOf_PassesOnFirstPassingBranch(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{AnyOf: []spec.Assertion{
+		{Command: "assert_equals a b"},
+		{Command: "assert_equals a a"},
+		{Command: "assert_equals b b"},
+	}})
+	fakeExecutor := &fakeexec.FakeExecutor{ExitCodes: map[string]int{"assert_equals a b": 1}}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	require.Len(t, fakeExecutor.Commands, 3)
+	starts := findEvents[*event.AssertionStartEvent](sink.Events)
+	assert.Equal(t, "any_of(assert_equals a b; assert_equals a a; assert_equals b b)", starts[0].Command)
+	assert.Equal(t, "PASS: any_of[1] assert_equals a a passed\n", phaseOutput(sink.Events, "_assertions/0"))
+	assert.Equal(t, 1, runner.Passed())
+}
+
+func TestRunner_AnyOf_FailsWithEveryBranchOutput(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{AnyOf: []spec.Assertion{
+		{Command: "assert_equals a b"},
+		{Command: "assert_equals a c"},
+	}})
+	fakeExecutor := &fakeexec.FakeExecutor{Stdout: "FAIL: values differ\n", DefaultExitCode: 1}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Equal(t, "FAIL: none of 2 any_of branches passed\n"+
+		"──────────────────────────────────\n"+
+		"any_of[0] assert_equals a b\n"+
+		"  FAIL: values differ\n"+
+		"\n"+
+		"any_of[1] assert_equals a c\n"+
+		"  FAIL: values differ\n", phaseOutput(sink.Events, "_assertions/0"))
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func TestRunner_AllOf_FailsOnFirstFailingBranchAndInheritsTimeout(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Timeout: "3s", AllOf: []spec.Assertion{
+		{Command: "assert_equals a a"},
+		{Command: "assert_equals a b", Timeout: "1s"},
+		{Command: "assert_equals b b"},
+	}})
+	fakeExecutor := &fakeexec.FakeExecutor{Stdout: "out\n", ExitCodes: map[string]int{"assert_equals a b": 1}}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	require.Len(t, fakeExecutor.Commands, 3)
+	assert.Equal(t, "3s", fakeExecutor.Commands[1].Timeout)
+	assert.Equal(t, "1s", fakeExecutor.Commands[2].Timeout)
+	assert.Equal(t, "FAIL: all_of[1] assert_equals a b failed\n"+
+		"──────────────────────────────────\n"+
+		"all_of[1] assert_equals a b\n"+
+		"  out\n", phaseOutput(sink.Events, "_assertions/0"))
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func TestRunner_NegatedAllOf_PassesWhenABranchFails(t *testing.T) {
+	specTree := pollingSpec(spec.Assertion{Not: true, AllOf: []spec.Assertion{
+		{Command: "assert_equals a a"},
+		{Command: "assert_equals a b"},
+	}})
+	fakeExecutor := &fakeexec.FakeExecutor{ExitCodes: map[string]int{"assert_equals a b": 1}}
+	sink := &SpySink{}
+	runner := NewRunner(fakeExecutor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Equal(t, "PASS: not all_of(assert_equals a a; assert_equals a b)\n", phaseOutput(sink.Events, "_assertions/0"))
+	assert.Equal(t, 1, runner.Passed())
+}
+
+type concurrencyExecutor struct {
+	fakeexec.FakeExecutor
+	mutex   sync.Mutex
+	running int
+	peak    int
+}
+
+func (tracker *concurrencyExecutor) Execute(command string, timeout string, env map[string]string) (string, string, int, error) {
+	tracker.mutex.Lock()
+	tracker.running++
+	tracker.peak = max(tracker.peak, tracker.running)
+	tracker.mutex.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	tracker.mutex.Lock()
+	tracker.running--
+	tracker.mutex.Unlock()
+	return tracker.FakeExecutor.Execute(command, timeout, env)
+}
+
+func (tracker *concurrencyExecutor) ExecuteStreaming(command string, timeout string, env map[string]string, stdin string, onOutput executor.OutputHandler) (string, string, int, error) {
+	return tracker.Execute(command, timeout, env)
+}
+
+func withParallelScenarios(t *tree.SpecTree, count int) *tree.SpecTree {
+	t.Context.Parallel = true
+	t.Context.Scenarios = nil
+	for index := 0; index < count; index++ {
+		id := fmt.Sprintf("scenario%d", index)
+		t.Context.Scenarios = append(t.Context.Scenarios, spec.Scenario{
+			ID:   id,
+			Name: id,
+			Run:  &spec.RunBlock{Command: "cmd_" + id, Timeout: "5s"},
+		})
+	}
+	return t
+}
+
+func runParallelSpec(t *testing.T, specTree *tree.SpecTree, jobs int) (*concurrencyExecutor, *SpySink, *Runner) {
+	executor := &concurrencyExecutor{}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	runner.Jobs = jobs
+
+	err := runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+	require.NoError(t, err)
+
+	return executor, sink, runner
+}
+
+func TestRunner_Parallel_RunsSiblingLeavesConcurrently(t *testing.T) {
+	specTree := withParallelScenarios(newSpecTree("basic"), 4)
+
+	executor, _, runner := runParallelSpec(t, specTree, 4)
+
+	assert.Len(t, executor.Commands, 4)
+	assert.Greater(t, executor.peak, 1)
+	assert.Equal(t, 4, runner.Passed())
+}
+
+func TestRunner_Parallel_BoundedByJobs(t *testing.T) {
+	specTree := withParallelScenarios(newSpecTree("basic"), 6)
+
+	executor, _, _ := runParallelSpec(t, specTree, 2)
+
+	assert.Len(t, executor.Commands, 6)
+	assert.LessOrEqual(t, executor.peak, 2)
+}
+
+func TestRunner_Parallel_IgnoredWithoutJobs(t *testing.T) {
+	specTree := withParallelScenarios(newSpecTree("basic"), 3)
+
+	executor, _, _ := runParallelSpec(t, specTree, 1)
+
+	assert.Equal(t, 1, executor.peak)
+	assert.Equal(t, "cmd_scenario0", executor.Commands[0].Command)
+	assert.Equal(t, "cmd_scenario1", executor.Commands[1].Command)
+	assert.Equal(t, "cmd_scenario2", executor.Commands[2].Command)
+}
+
+func TestRunner_Parallel_ContextHooksWrapAllLeaves(t *testing.T) {
+	specTree := withParallelScenarios(newSpecTree("basic"), 4)
+	withBeforeHook(specTree, "setup.sh")
+	withAfterHook(specTree, "teardown.sh")
+
+	executor, _, _ := runParallelSpec(t, specTree, 4)
+
+	require.Len(t, executor.Commands, 6)
+	assert.Equal(t, "setup.sh", executor.Commands[0].Command)
+	assert.Equal(t, "teardown.sh", executor.Commands[5].Command)
+}
+
+func TestRunner_Parallel_KeepsScenarioEventsContiguous(t *testing.T) {
+	specTree := withParallelScenarios(newSpecTree("basic"), 4)
+	withBeforeEachHook(specTree, "reset.sh")
+
+	_, sink, _ := runParallelSpec(t, specTree, 4)
+
+	currentPath := ""
+	for _, emitted := range sink.Events {
+		switch typed := emitted.(type) {
+		case *event.ScenarioEnterEvent:
+			assert.Empty(t, currentPath)
+			currentPath = typed.Path
+		case *event.HookStartEvent:
+			assert.Equal(t, currentPath, typed.Path)
+		case *event.ScenarioRunEndEvent:
+			assert.Equal(t, currentPath, typed.Path)
+		case *event.ScenarioExitEvent:
+			assert.Equal(t, currentPath, typed.Path)
+			currentPath = ""
+		}
+	}
+	assert.Len(t, findEvents[*event.ScenarioExitEvent](sink.Events), 4)
+}
+
+func TestRunner_Parallel_CountsFailuresAcrossWorkers(t *testing.T) {
+	specTree := withParallelScenarios(newSpecTree("basic"), 4)
+	for index := range specTree.Context.Scenarios {
+		specTree.Context.Scenarios[index].Assertions = []spec.Assertion{{Command: "assert_fails", Timeout: "1s"}}
+	}
+	specTree.Context.OnFailure = "continue"
+	executor := &concurrencyExecutor{FakeExecutor: fakeexec.FakeExecutor{ExitCodes: map[string]int{"assert_fails": 1}}}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	runner.Jobs = 4
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Equal(t, 0, runner.Passed())
+	assert.Equal(t, 4, runner.Failed())
+	runEnd := findEvents[*event.RunEndEvent](sink.Events)
+	require.Len(t, runEnd, 1)
+	assert.Equal(t, 4, runEnd[0].Failed)
+}
+
+func TestRunner_Parallel_GroupRunsChildrenConcurrently(t *testing.T) {
+	specTree := withNestedScenario(newSpecTree("basic"))
+	specTree.Context.Scenarios[0].Parallel = true
+
+	executor, _, runner := runParallelSpec(t, specTree, 2)
+
+	assert.Len(t, executor.Commands, 2)
+	assert.Equal(t, 2, executor.peak)
+	assert.Equal(t, 2, runner.Passed())
+}
+
+func runSpecWithExitCodes(t *testing.T, specTree *tree.SpecTree, exitCodes map[string]int) (*fakeexec.FakeExecutor, *SpySink, *Runner) {
+	executor := &fakeexec.FakeExecutor{ExitCodes: exitCodes}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+
+	err := runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+	require.NoError(t, err)
+
+	return executor, sink, runner
+}
+
+func executedCommands(executor *fakeexec.FakeExecutor) []string {
+	var commands []string
+	for _, command := range executor.Commands {
+		commands = append(commands, command.Command)
+	}
+	return commands
+}
+
+func TestRunner_FailingBeforeEach_ErrorsLeafWithoutRunning(t *testing.T) {
+	specTree := withAfterEachHook(withBeforeEachHook(newSpecTree("basic"), "reset.sh"), "cleanup.sh")
+
+	executor, sink, runner := runSpecWithExitCodes(t, specTree, map[string]int{"reset.sh": 1})
+
+	assert.Equal(t, []string{"reset.sh", "cleanup.sh"}, executedCommands(executor))
+	exits := findEvents[*event.ScenarioExitEvent](sink.Events)
+	require.Len(t, exits, 1)
+	assert.Equal(t, "error", exits[0].Status)
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func TestRunner_FailingOuterBeforeEach_TearsDownOnlyStartedLevels(t *testing.T) {
+	specTree := newSpecTree("basic")
+	specTree.Context.BeforeEach = &spec.Hook{Run: "context_setup.sh"}
+	specTree.Context.AfterEach = &spec.Hook{Run: "context_cleanup.sh"}
+	specTree.Context.Scenarios = []spec.Scenario{
+		{
+			ID:         "group",
+			BeforeEach: &spec.Hook{Run: "group_setup.sh"},
+			AfterEach:  &spec.Hook{Run: "group_cleanup.sh"},
+			Scenarios: []spec.Scenario{{
+				ID:     "leaf",
+				Before: &spec.Hook{Run: "leaf_setup.sh"},
+				After:  &spec.Hook{Run: "leaf_cleanup.sh"},
+				Run:    &spec.RunBlock{Command: "leaf_cmd"},
+			}},
+		},
+	}
+
+	executor, sink, _ := runSpecWithExitCodes(t, specTree, map[string]int{"context_setup.sh": 1})
+
+	assert.Equal(t, []string{"context_setup.sh", "context_cleanup.sh"}, executedCommands(executor))
+	exits := findEvents[*event.ScenarioExitEvent](sink.Events)
+	require.Len(t, exits, 1)
+	assert.Equal(t, "error", exits[0].Status)
+}
+
+func TestRunner_FailingInnerBeforeEach_TearsDownOuterLevels(t *testing.T) {
+	specTree := newSpecTree("basic")
+	specTree.Context.AfterEach = &spec.Hook{Run: "context_cleanup.sh"}
+	specTree.Context.Scenarios = []spec.Scenario{
+		{
+			ID:         "group",
+			BeforeEach: &spec.Hook{Run: "group_setup.sh"},
+			AfterEach:  &spec.Hook{Run: "group_cleanup.sh"},
+			Scenarios: []spec.Scenario{{
+				ID:    "leaf",
+				After: &spec.Hook{Run: "leaf_cleanup.sh"},
+				Run:   &spec.RunBlock{Command: "leaf_cmd"},
+			}},
+		},
+	}
+
+	executor, _, _ := runSpecWithExitCodes(t, specTree, map[string]int{"group_setup.sh": 1})
+
+	assert.Equal(t, []string{"group_setup.sh", "group_cleanup.sh", "context_cleanup.sh"}, executedCommands(executor))
+}
+
+func TestRunner_FailingBeforeEach_OnlyFailsThatLeaf(t *testing.T) {
+	specTree := withTwoScenarios(newSpecTree("basic"))
+	specTree.Context.Scenarios[0].Before = &spec.Hook{Run: "broken_setup.sh"}
+
+	executor, sink, runner := runSpecWithExitCodes(t, specTree, map[string]int{"broken_setup.sh": 1})
+
+	assert.Equal(t, []string{"broken_setup.sh", "cmd2"}, executedCommands(executor))
+	exits := findEvents[*event.ScenarioExitEvent](sink.Events)
+	require.Len(t, exits, 2)
+	assert.Equal(t, "error", exits[0].Status)
+	assert.Equal(t, "pass", exits[1].Status)
+	assert.Equal(t, 1, runner.Passed())
+}
+
+func TestRunner_FailingAfterHook_ReportsTeardownError(t *testing.T) {
+	specTree := newSpecTree("basic")
+	specTree.Context.Scenarios[0].After = &spec.Hook{Run: "teardown.sh"}
+
+	_, sink, runner := runSpecWithExitCodes(t, specTree, map[string]int{"teardown.sh": 1})
+
+	exits := findEvents[*event.ScenarioExitEvent](sink.Events)
+	require.Len(t, exits, 1)
+	assert.Equal(t, "error", exits[0].Status)
+	assert.Equal(t, 1, runner.Failed())
+}
+
+func TestRunner_ScenarioExitFollowsTeardownHooks(t *testing.T) {
+	specTree := withAfterEachHook(newSpecTree("basic"), "cleanup.sh")
+
+	_, sink := runSpec(t, specTree)
+
+	lastHook, exitIndex := -1, -1
+	for index, emitted := range sink.Events {
+		switch emitted.(type) {
+		case *event.HookEndEvent:
+			lastHook = index
+		case *event.ScenarioExitEvent:
+			exitIndex = index
+		}
+	}
+	assert.Greater(t, exitIndex, lastHook)
+}
+
+func TestRunner_FailingContextBefore_SkipsDescendantsWithoutRunning(t *testing.T) {
+	specTree := withAfterHook(withBeforeHook(withChildContext(newSpecTree("root"), "child"), "start-server.sh"), "stop-server.sh")
+
+	executor, sink, runner := runSpecWithExitCodes(t, specTree, map[string]int{"start-server.sh": 1})
+
+	assert.Equal(t, []string{"start-server.sh", "stop-server.sh"}, executedCommands(executor))
+	assert.Empty(t, findEvents[*event.ScenarioExitEvent](sink.Events))
+	skipped := findEvents[*event.ScenarioSkippedEvent](sink.Events)
+	require.Len(t, skipped, 2)
+	assert.Equal(t, "root/scenario", skipped[0].Path)
+	assert.Equal(t, "hook_failure", skipped[0].Reason)
+	assert.Equal(t, "root/child/child_scenario", skipped[1].Path)
+	assert.Equal(t, "hook_failure", skipped[1].Reason)
+	assert.Len(t, findEvents[*event.ContextEnterEvent](sink.Events), 2)
+	assert.Equal(t, 1, runner.HookErrors())
+	assert.Equal(t, 2, runner.Skipped())
+	runEnd := findEvents[*event.RunEndEvent](sink.Events)
+	require.Len(t, runEnd, 1)
+	assert.Equal(t, "fail", runEnd[0].Status)
+}
+
+func TestRunner_FailingContextAfter_FailsRun(t *testing.T) {
+	specTree := withAfterHook(newSpecTree("basic"), "stop-server.sh")
+
+	_, sink, runner := runSpecWithExitCodes(t, specTree, map[string]int{"stop-server.sh": 1})
+
+	assert.Equal(t, 1, runner.Passed())
+	assert.Equal(t, 1, runner.HookErrors())
+	runEnd := findEvents[*event.RunEndEvent](sink.Events)
+	require.Len(t, runEnd, 1)
+	assert.Equal(t, "fail", runEnd[0].Status)
+}
+
+func TestRunner_HookTimeout_EmitsTimeoutEventAndErrors(t *testing.T) {
+	specTree := withBeforeEachHook(newSpecTree("basic"), "slow_reset.sh")
+	executor := &fakeexec.FakeExecutor{
+		TimeoutCommands:  map[string]bool{"slow_reset.sh": true},
+		TimeoutExitCodes: map[string]int{"slow_reset.sh": 0},
+	}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+
+	runner.Run(specTree, absSpecPath(specTree))
+
+	timeouts := findEvents[*event.TimeoutEvent](sink.Events)
+	require.Len(t, timeouts, 1)
+	assert.Equal(t, "before_each", timeouts[0].Phase)
+	exits := findEvents[*event.ScenarioExitEvent](sink.Events)
+	require.Len(t, exits, 1)
+	assert.Equal(t, "error", exits[0].Status)
+}
+
+func skippedReasons(sink *SpySink) map[string]string {
+	reasons := make(map[string]string)
+	for _, skipped := range findEvents[*event.ScenarioSkippedEvent](sink.Events) {
+		reasons[skipped.Path] = skipped.Reason
+	}
+	return reasons
+}
+
+func TestRunner_SkipChildren_EmitsSkippedEvents(t *testing.T) {
+	specTree := withFailingAssertion(withTwoScenarios(newSpecTree("root")), 0)
+	specTree.Context.OnFailure = "skip_children"
+
+	_, sink, runner := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, map[string]string{"root/scenario2": "skip_children"}, skippedReasons(sink))
+	assert.Equal(t, 1, runner.Skipped())
+}
+
+func TestRunner_AbortRun_SkipsRemainingTree(t *testing.T) {
+	specTree := withFailingAssertion(withTwoScenarios(newSpecTree("root")), 0)
+	withChildContext(specTree, "child")
+	specTree.Context.OnFailure = "abort_run"
+
+	_, sink, runner := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, map[string]string{
+		"root/scenario2":            "abort_run",
+		"root/child/child_scenario": "abort_run",
+	}, skippedReasons(sink))
+	assert.Equal(t, 2, runner.Skipped())
+	runEnd := findEvents[*event.RunEndEvent](sink.Events)
+	require.Len(t, runEnd, 1)
+	assert.Equal(t, 2, runEnd[0].Skipped)
+}
+
+func TestRunner_Filter_EmitsSkippedEvents(t *testing.T) {
+	specTree := withTwoScenarios(newSpecTree("root"))
+	executor := &fakeexec.FakeExecutor{}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	runner.Filter = "root/scenario1"
+
+	runner.Run(specTree, absSpecPath(specTree))
+
+	assert.Equal(t, map[string]string{"root/scenario2": "filter"}, skippedReasons(sink))
+}
+
+func TestRunner_SkippedNestedGroup_SkipsEachLeaf(t *testing.T) {
+	specTree := withFailingAssertion(newSpecTree("root"), 0)
+	specTree.Context.OnFailure = "skip_children"
+	specTree.Context.Scenarios = append(specTree.Context.Scenarios, withNestedScenario(newSpecTree("other")).Context.Scenarios...)
+
+	_, sink, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, map[string]string{
+		"root/group/leaf1": "skip_children",
+		"root/group/leaf2": "skip_children",
+	}, skippedReasons(sink))
+}
+
+func withFailingGroup(t *tree.SpecTree, onFailure string) *tree.SpecTree {
+	t.Context.Scenarios = []spec.Scenario{
+		{
+			ID:        "group",
+			Name:      "Group",
+			OnFailure: onFailure,
+			Scenarios: []spec.Scenario{
+				{ID: "leaf1", Name: "Leaf 1", Run: &spec.RunBlock{Command: "fail_cmd", Timeout: "5s"}, Assertions: []spec.Assertion{
+					{Command: "assert_equals expected actual", Timeout: "1s"},
+				}},
+				{ID: "leaf2", Name: "Leaf 2", Run: &spec.RunBlock{Command: "leaf2_cmd", Timeout: "5s"}},
+			},
+		},
+		{ID: "after_group", Name: "After group", Run: &spec.RunBlock{Command: "after_group_cmd", Timeout: "5s"}},
+	}
+	return t
+}
+
+func TestRunner_GroupSkipChildren_SkipsOnlyRestOfGroup(t *testing.T) {
+	specTree := withFailingGroup(newSpecTree("root"), "skip_children")
+
+	executor, sink, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, []string{"fail_cmd", "assert_equals expected actual", "after_group_cmd"}, executedCommands(executor))
+	assert.Equal(t, map[string]string{"root/group/leaf2": "skip_children"}, skippedReasons(sink))
+}
+
+func TestRunner_GroupContinue_OverridesContextSkipChildren(t *testing.T) {
+	specTree := withFailingGroup(newSpecTree("root"), "continue")
+	specTree.Context.OnFailure = "skip_children"
+
+	executor, sink, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, []string{"fail_cmd", "assert_equals expected actual", "leaf2_cmd", "after_group_cmd"}, executedCommands(executor))
+	assert.Empty(t, skippedReasons(sink))
+}
+
+func TestRunner_Group_InheritsContextOnFailure(t *testing.T) {
+	specTree := withFailingGroup(newSpecTree("root"), "")
+	specTree.Context.OnFailure = "abort_run"
+
+	executor, sink, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, []string{"fail_cmd", "assert_equals expected actual"}, executedCommands(executor))
+	assert.Equal(t, map[string]string{
+		"root/group/leaf2": "abort_run",
+		"root/after_group": "abort_run",
+	}, skippedReasons(sink))
+}
+
+func TestRunner_Leaf_OverridesGroupOnFailure(t *testing.T) {
+	specTree := withFailingGroup(newSpecTree("root"), "skip_children")
+	specTree.Context.Scenarios[0].Scenarios[0].OnFailure = "continue"
+
+	executor, _, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, []string{"fail_cmd", "assert_equals expected actual", "leaf2_cmd", "after_group_cmd"}, executedCommands(executor))
+}
+
+func TestRunner_Leaf_OwnSkipChildrenIsTheSkipReason(t *testing.T) {
+	specTree := withFailingGroup(newSpecTree("root"), "")
+	specTree.Context.Scenarios[0].Scenarios[0].OnFailure = "skip_children"
+
+	_, sink, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, map[string]string{"root/group/leaf2": "skip_children"}, skippedReasons(sink))
+}
+
+func TestRunner_Leaf_OwnSkipChildrenUnderContinueContext_SkipsChildContexts(t *testing.T) {
+	specTree := withFailingAssertion(withTwoScenarios(withChildContext(newSpecTree("root"), "child")), 0)
+	specTree.Context.OnFailure = "continue"
+	specTree.Context.Scenarios[0].OnFailure = "skip_children"
+
+	_, sink, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, map[string]string{
+		"root/scenario2":            "skip_children",
+		"root/child/child_scenario": "skip_children",
+	}, skippedReasons(sink))
+}
+
+func TestRunner_ChildContext_InheritsParentOnFailure(t *testing.T) {
+	specTree := withChildContext(newSpecTree("root"), "child")
+	specTree.Context.OnFailure = "skip_children"
+	withFailingGroup(specTree.Children[0], "")
+
+	executor, _, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, []string{"test_command", "fail_cmd", "assert_equals expected actual", "after_group_cmd"}, executedCommands(executor))
+}
+
+func TestRunner_ChildContext_OverridesParentOnFailure(t *testing.T) {
+	specTree := withChildContext(newSpecTree("root"), "child")
+	specTree.Context.OnFailure = "skip_children"
+	withFailingGroup(specTree.Children[0], "")
+	specTree.Children[0].Context.OnFailure = "continue"
+
+	executor, _, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, []string{"test_command", "fail_cmd", "assert_equals expected actual", "leaf2_cmd", "after_group_cmd"}, executedCommands(executor))
+}
+
+func TestRunner_ContextSkipChildren_SkipsChildContexts(t *testing.T) {
+	specTree := withFailingAssertion(withChildContext(newSpecTree("root"), "child"), 0)
+	specTree.Context.OnFailure = "skip_children"
+
+	executor, sink, _ := runSpecWithExitCodes(t, specTree, map[string]int{"assert_equals expected actual": 1})
+
+	assert.Equal(t, []string{"test_command", "assert_equals expected actual"}, executedCommands(executor))
+	assert.Equal(t, map[string]string{"root/child/child_scenario": "skip_children"}, skippedReasons(sink))
+}
+
+type flakyExecutor struct {
+	fakeexec.FakeExecutor
+	command  string
+	failures int
+}
+
+func (flaky *flakyExecutor) Execute(command string, timeout string, env map[string]string) (string, string, int, error) {
+	stdout, stderr, exitCode, err := flaky.FakeExecutor.Execute(command, timeout, env)
+	if command == flaky.command && flaky.failures > 0 {
+		flaky.failures--
+		return stdout, stderr, 1, err
+	}
+	return stdout, stderr, exitCode, err
+}
+
+func (flaky *flakyExecutor) ExecuteStreaming(command string, timeout string, env map[string]string, stdin string, onOutput executor.OutputHandler) (string, string, int, error) {
+	return flaky.Execute(command, timeout, env)
+}
+
+func runFlakySpec(t *testing.T, specTree *tree.SpecTree, failures int) (*flakyExecutor, *SpySink, *Runner, []time.Duration) {
+	executor := &flakyExecutor{command: "assert_equals expected actual", failures: failures}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	var delays []time.Duration
+	runner.sleep = func(delay time.Duration) { delays = append(delays, delay) }
+
+	err := runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+	require.NoError(t, err)
+
+	return executor, sink, runner, delays
+}
+
+func exitStatuses(sink *SpySink) []string {
+	var statuses []string
+	for _, exit := range findEvents[*event.ScenarioExitEvent](sink.Events) {
+		statuses = append(statuses, fmt.Sprintf("%d:%s", exit.Attempt, exit.Status))
+	}
+	return statuses
+}
+
+func TestRunner_Retries_PassingRetryIsFlaky(t *testing.T) {
+	specTree := withFailingAssertion(newSpecTree("root"), 0)
+	specTree.Context.Scenarios[0].Retries = 2
+
+	_, sink, runner, _ := runFlakySpec(t, specTree, 1)
+
+	assert.Equal(t, []string{"1:retry", "2:flaky"}, exitStatuses(sink))
+	enters := findEvents[*event.ScenarioEnterEvent](sink.Events)
+	require.Len(t, enters, 2)
+	assert.Equal(t, 2, enters[1].Attempt)
+	assert.Equal(t, 1, runner.Passed())
+	assert.Equal(t, 1, runner.Flaky())
+	assert.Equal(t, 0, runner.Failed())
+	runEnd := findEvents[*event.RunEndEvent](sink.Events)[0]
+	assert.Equal(t, "pass", runEnd.Status)
+	assert.Equal(t, 1, runEnd.Flaky)
+}
+
+func TestRunner_Retries_FailsAfterExhaustingAttempts(t *testing.T) {
+	specTree := withFailingAssertion(newSpecTree("root"), 0)
+	specTree.Context.Scenarios[0].Retries = 2
+
+	executor, sink, runner, _ := runFlakySpec(t, specTree, 5)
+
+	assert.Len(t, executor.Commands, 6)
+	assert.Equal(t, []string{"1:retry", "2:retry", "3:fail"}, exitStatuses(sink))
+	assert.Equal(t, 1, runner.Failed())
+	assert.Equal(t, 0, runner.Flaky())
+}
+
+func TestRunner_Retries_RerunSetupAndTeardownHooks(t *testing.T) {
+	specTree := withFailingAssertion(withAfterEachHook(withBeforeEachHook(newSpecTree("root"), "reset.sh"), "cleanup.sh"), 0)
+	specTree.Context.Scenarios[0].Before = &spec.Hook{Run: "seed.sh", Timeout: "1s"}
+	specTree.Context.Scenarios[0].Retries = 1
+
+	executor, _, _, _ := runFlakySpec(t, specTree, 1)
+
+	attempt := []string{"reset.sh", "seed.sh", "test_command", "assert_equals expected actual", "cleanup.sh"}
+	assert.Equal(t, append(attempt, attempt...), executedCommands(&executor.FakeExecutor))
+}
+
+func TestRunner_Retries_WaitsRetryDelayBetweenAttempts(t *testing.T) {
+	specTree := withFailingAssertion(newSpecTree("root"), 0)
+	specTree.Context.Scenarios[0].Retries = 3
+	specTree.Context.Scenarios[0].RetryDelay = "250ms"
+
+	_, _, _, delays := runFlakySpec(t, specTree, 2)
+
+	assert.Equal(t, []time.Duration{250 * time.Millisecond, 250 * time.Millisecond}, delays)
+}
+
+func TestRunner_Retries_RunBlockOverridesScenario(t *testing.T) {
+	specTree := withFailingAssertion(newSpecTree("root"), 0)
+	specTree.Context.Scenarios[0].Retries = 5
+	specTree.Context.Scenarios[0].RetryDelay = "1s"
+	specTree.Context.Scenarios[0].Run.Retries = 1
+	specTree.Context.Scenarios[0].Run.RetryDelay = "10ms"
+
+	_, sink, _, delays := runFlakySpec(t, specTree, 5)
+
+	assert.Equal(t, []string{"1:retry", "2:fail"}, exitStatuses(sink))
+	assert.Equal(t, []time.Duration{10 * time.Millisecond}, delays)
+}
+
+func withService(t *tree.SpecTree, service spec.Service) *tree.SpecTree {
+	t.Context.Services = append(t.Context.Services, service)
+	return t
+}
+
+func runServiceSpec(t *testing.T, specTree *tree.SpecTree, executor *fakeexec.FakeExecutor) (*SpySink, *Runner) {
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	runner.sleep = func(time.Duration) {}
+
+	require.NoError(t, runner.Run(specTree, "/"+specTree.Path))
+	return sink, runner
+}
+
+func TestRunner_Services_StartBeforeHooksAndStopAfterContext(t *testing.T) {
+	specTree := withAfterHook(withBeforeHook(newSpecTree("api"), "seed.sh"), "cleanup.sh")
+	withService(specTree, spec.Service{Name: "db", Run: "start_db"})
+	executor := &fakeexec.FakeExecutor{}
+
+	sink, runner := runServiceSpec(t, specTree, executor)
+
+	assert.Equal(t, []string{"start_db", "seed.sh", "test_command", "cleanup.sh"}, executedCommands(executor))
+	require.Len(t, executor.Processes, 1)
+	assert.True(t, executor.Processes[0].Stopped)
+	assert.Equal(t, 1, runner.Passed())
+
+	stops := findEvents[*event.ServiceStopEvent](sink.Events)
+	require.Len(t, stops, 1)
+	assert.Equal(t, "teardown", stops[0].Reason)
+	exits := findEvents[*event.ContextExitEvent](sink.Events)
+	assert.Greater(t, indexOfEvent(sink.Events, exits[0]), indexOfEvent(sink.Events, stops[0]))
+}
+
+func TestRunner_Services_ExportEnvToScenarios(t *testing.T) {
+	specTree := withService(newSpecTree("api"), spec.Service{
+		Name: "db",
+		Run:  "start_db --port ${DB_PORT}",
+		Env:  map[string]string{"DB_PORT": "5433"},
+	})
+	executor := &fakeexec.FakeExecutor{}
+
+	runServiceSpec(t, specTree, executor)
+
+	assert.Equal(t, "start_db --port 5433", executor.Commands[0].Command)
+	assert.Equal(t, "5433", executor.Commands[1].Env["DB_PORT"])
+}
+
+func TestRunner_Services_WaitForLogReadiness(t *testing.T) {
+	specTree := withService(newSpecTree("api"), spec.Service{
+		Name:  "web",
+		Run:   "start_web",
+		Ready: &spec.Readiness{Log: "listening on \\d+"},
+	})
+	executor := &fakeexec.FakeExecutor{ServiceOutput: map[string]string{"start_web": "listening on 8080\n"}}
+
+	sink, runner := runServiceSpec(t, specTree, executor)
+
+	ready := findEvents[*event.ServiceReadyEvent](sink.Events)
+	require.Len(t, ready, 1)
+	assert.Equal(t, "web", ready[0].Service)
+	outputs := findEvents[*event.OutputEvent](sink.Events)
+	require.NotEmpty(t, outputs)
+	assert.Equal(t, "_services/web", outputs[0].Phase)
+	assert.Equal(t, 1, runner.Passed())
+}
+
+func TestRunner_Services_CommandProbeRetriesUntilTimeout(t *testing.T) {
+	specTree := withService(newSpecTree("api"), spec.Service{
+		Name:    "db",
+		Run:     "start_db",
+		Timeout: "1ms",
+		Ready:   &spec.Readiness{Command: "pg_isready"},
+	})
+	executor := &fakeexec.FakeExecutor{ExitCodes: map[string]int{"pg_isready": 1}}
+
+	sink, runner := runServiceSpec(t, specTree, executor)
+
+	stops := findEvents[*event.ServiceStopEvent](sink.Events)
+	require.Len(t, stops, 1)
+	assert.Equal(t, "startup_timeout", stops[0].Reason)
+	assert.True(t, executor.Processes[0].Stopped)
+	skipped := findEvents[*event.ScenarioSkippedEvent](sink.Events)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "service_failure", skipped[0].Reason)
+	assert.Equal(t, 1, runner.HookErrors())
+}
+
+func TestRunner_Services_ReadinessDeadlineUsesRunnerClock(t *testing.T) {
+	specTree := withService(newSpecTree("api"), spec.Service{
+		Name:    "db",
+		Run:     "start_db",
+		Timeout: "2s",
+		Ready:   &spec.Readiness{Command: "pg_isready", Interval: "500ms"},
+	})
+	executor := &fakeexec.FakeExecutor{ExitCodes: map[string]int{"pg_isready": 1}}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	clock := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runner.now = func() time.Time { return clock }
+	runner.sleep = func(delay time.Duration) { clock = clock.Add(delay) }
+
+	require.NoError(t, runner.Run(specTree, "/"+specTree.Path))
+
+	probes := 0
+	for _, command := range executedCommands(executor) {
+		if command == "pg_isready" {
+			probes++
+		}
+	}
+	assert.Equal(t, 6, probes)
+	stops := findEvents[*event.ServiceStopEvent](sink.Events)
+	require.Len(t, stops, 1)
+	assert.Equal(t, "startup_timeout", stops[0].Reason)
+}
+
+func TestRunner_Services_ExitBeforeReadySkipsContext(t *testing.T) {
+	specTree := withBeforeHook(newSpecTree("api"), "seed.sh")
+	withService(specTree, spec.Service{Name: "db", Run: "start_db", Ready: &spec.Readiness{Log: "ready"}})
+	executor := &fakeexec.FakeExecutor{ExitedServices: map[string]int{"start_db": 2}}
+
+	sink, runner := runServiceSpec(t, specTree, executor)
+
+	stops := findEvents[*event.ServiceStopEvent](sink.Events)
+	require.Len(t, stops, 1)
+	assert.Equal(t, "exited", stops[0].Reason)
+	assert.Equal(t, 2, stops[0].ExitCode)
+	assert.Empty(t, findEvents[*event.HookStartEvent](sink.Events))
+	assert.Equal(t, 0, runner.Passed())
+	assert.Equal(t, 1, runner.Skipped())
+}
+
+func TestRunner_Services_FailedServiceSkipsContextAfterHook(t *testing.T) {
+	specTree := withAfterHook(withBeforeHook(newSpecTree("api"), "seed.sh"), "cleanup.sh")
+	withService(specTree, spec.Service{Name: "db", Run: "start_db", Ready: &spec.Readiness{Log: "ready"}})
+	executor := &fakeexec.FakeExecutor{ExitedServices: map[string]int{"start_db": 1}}
+
+	sink, _ := runServiceSpec(t, specTree, executor)
+
+	assert.Equal(t, []string{"start_db"}, executedCommands(executor))
+	assert.Empty(t, findEvents[*event.HookStartEvent](sink.Events))
+	require.Len(t, findEvents[*event.ServiceStopEvent](sink.Events), 1)
+	require.Len(t, findEvents[*event.ContextExitEvent](sink.Events), 1)
+}
+
+func TestRunner_Services_LaterServicesNotStartedAfterFailure(t *testing.T) {
+	specTree := newSpecTree("api")
+	withService(specTree, spec.Service{Name: "db", Run: "start_db"})
+	withService(specTree, spec.Service{Name: "cache", Run: "start_cache", Ready: &spec.Readiness{Log: "ready"}})
+	withService(specTree, spec.Service{Name: "web", Run: "start_web"})
+	executor := &fakeexec.FakeExecutor{ExitedServices: map[string]int{"start_cache": 1}}
+
+	sink, _ := runServiceSpec(t, specTree, executor)
+
+	require.Len(t, executor.Processes, 2)
+	assert.True(t, executor.Processes[0].Stopped)
+	stops := findEvents[*event.ServiceStopEvent](sink.Events)
+	require.Len(t, stops, 2)
+	assert.Equal(t, "cache", stops[0].Service)
+	assert.Equal(t, "db", stops[1].Service)
+	assert.Equal(t, "teardown", stops[1].Reason)
+}
+
+func TestRunner_Services_StoppedInReverseOrder(t *testing.T) {
+	specTree := newSpecTree("api")
+	withService(specTree, spec.Service{Name: "db", Run: "start_db"})
+	withService(specTree, spec.Service{Name: "web", Run: "start_web"})
+
+	sink, _ := runServiceSpec(t, specTree, &fakeexec.FakeExecutor{})
+
+	stops := findEvents[*event.ServiceStopEvent](sink.Events)
+	require.Len(t, stops, 2)
+	assert.Equal(t, "web", stops[0].Service)
+	assert.Equal(t, "db", stops[1].Service)
+}
+
+type interruptingExecutor struct {
+	fakeexec.FakeExecutor
+	runner  *Runner
+	trigger string
+	panics  bool
+}
+
+func (interrupter *interruptingExecutor) Execute(command string, timeout string, env map[string]string) (string, string, int, error) {
+	if command == interrupter.trigger {
+		if interrupter.panics {
+			panic("boom")
+		}
+		interrupter.runner.Interrupt("interrupt")
+		interrupter.FakeExecutor.Execute(command, timeout, env)
+		return "", "", -1, executor.ErrInterrupted
+	}
+	return interrupter.FakeExecutor.Execute(command, timeout, env)
+}
+
+func (interrupter *interruptingExecutor) ExecuteStreaming(command string, timeout string, env map[string]string, stdin string, onOutput executor.OutputHandler) (string, string, int, error) {
+	return interrupter.Execute(command, timeout, env)
+}
+
+func runInterruptedSpec(specTree *tree.SpecTree, trigger string, panics bool) (*interruptingExecutor, *SpySink, *Runner, error) {
+	executor := &interruptingExecutor{trigger: trigger, panics: panics}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	executor.runner = runner
+
+	err := runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+	return executor, sink, runner, err
+}
+
+func TestRunner_Interrupt_SkipsRemainingScenariosAndRunsTeardown(t *testing.T) {
+	specTree := withAfterEachHook(withAfterHook(withTwoScenarios(newSpecTree("root")), "stop_server"), "reset")
+	withChildContext(specTree, "child")
+
+	executor, sink, runner, err := runInterruptedSpec(specTree, "cmd1", false)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cmd1", "reset", "stop_server"}, executedCommands(&executor.FakeExecutor))
+	assert.Equal(t, 1, executor.Interrupts)
+	assert.Equal(t, "signal", runner.Interruption())
+	assert.Equal(t, 1, runner.Failed())
+
+	interrupted := findEvents[*event.RunInterruptedEvent](sink.Events)
+	require.Len(t, interrupted, 1)
+	assert.Equal(t, "signal", interrupted[0].Reason)
+	assert.Equal(t, "interrupt", interrupted[0].Detail)
+
+	skipped := findEvents[*event.ScenarioSkippedEvent](sink.Events)
+	require.Len(t, skipped, 2)
+	assert.Equal(t, "root/scenario2", skipped[0].Path)
+	assert.Equal(t, "interrupted", skipped[0].Reason)
+	assert.Equal(t, "root/child/child_scenario", skipped[1].Path)
+	assert.Equal(t, "interrupted", skipped[1].Reason)
+
+	runEnd := findEvents[*event.RunEndEvent](sink.Events)
+	require.Len(t, runEnd, 1)
+	assert.Equal(t, "interrupted", runEnd[0].Status)
+}
+
+func TestRunner_Interrupt_DuringBeforeHookSkipsContextWithoutHookError(t *testing.T) {
+	specTree := withAfterHook(withBeforeHook(newSpecTree("root"), "start_server"), "stop_server")
+
+	executor, sink, runner, _ := runInterruptedSpec(specTree, "start_server", false)
+
+	assert.Equal(t, []string{"start_server", "stop_server"}, executedCommands(&executor.FakeExecutor))
+	assert.Equal(t, 0, runner.HookErrors())
+	skipped := findEvents[*event.ScenarioSkippedEvent](sink.Events)
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "interrupted", skipped[0].Reason)
+}
+
+func TestRunner_Panic_UnwindsEnteredContextsInReverseOrder(t *testing.T) {
+	specTree := withAfterHook(newEmptySpecTree("root"), "root_after")
+	withChildContext(specTree, "child")
+	child := specTree.Children[0]
+	child.Context.After = &spec.Hook{Run: "child_after", Timeout: "5s"}
+	child.Context.AfterEach = &spec.Hook{Run: "child_after_each", Timeout: "5s"}
+
+	executor, sink, runner, err := runInterruptedSpec(specTree, "child_command", true)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+	assert.Equal(t, "panic", runner.Interruption())
+	assert.Equal(t, []string{"child_after_each", "child_after", "root_after"}, executedCommands(&executor.FakeExecutor))
+
+	exits := findEvents[*event.ContextExitEvent](sink.Events)
+	require.Len(t, exits, 2)
+	assert.Equal(t, "root/child", exits[0].Path)
+	assert.Equal(t, "root", exits[1].Path)
+
+	interrupted := findEvents[*event.RunInterruptedEvent](sink.Events)
+	require.Len(t, interrupted, 1)
+	assert.Equal(t, "boom", interrupted[0].Detail)
+	runEnd := findEvents[*event.RunEndEvent](sink.Events)
+	require.Len(t, runEnd, 1)
+	assert.Equal(t, "interrupted", runEnd[0].Status)
+}
+
+func TestRunner_Panic_InParallelWorkerStillUnwinds(t *testing.T) {
+	specTree := withAfterHook(withParallelScenarios(newSpecTree("root"), 3), "root_after")
+
+	executor := &interruptingExecutor{trigger: "cmd_scenario1", panics: true}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+	runner.Jobs = 3
+
+	err := runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	require.Error(t, err)
+	assert.Contains(t, executedCommands(&executor.FakeExecutor), "root_after")
+	assert.Len(t, findEvents[*event.ContextExitEvent](sink.Events), 1)
+	assert.Len(t, findEvents[*event.RunEndEvent](sink.Events), 1)
+}
+
+func TestRunner_AbortRun_EmitsInterruptedEventAndRunsAncestorTeardown(t *testing.T) {
+	specTree := withAfterHook(newEmptySpecTree("root"), "root_after")
+	specTree.Context.OnFailure = "abort_run"
+	withChildContext(specTree, "child")
+	specTree.Children[0].Context.After = &spec.Hook{Run: "child_after", Timeout: "5s"}
+	specTree.Children[0].Context.Scenarios[0].Assertions = []spec.Assertion{{Command: "check", Timeout: "1s"}}
+	executor := &fakeexec.FakeExecutor{ExitCodes: map[string]int{"check": 1}}
+	sink := &SpySink{}
+	runner := NewRunner(executor, sink)
+
+	runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+
+	assert.Equal(t, []string{"child_command", "check", "child_after", "root_after"}, executedCommands(executor))
+	interrupted := findEvents[*event.RunInterruptedEvent](sink.Events)
+	require.Len(t, interrupted, 1)
+	assert.Equal(t, "abort_run", interrupted[0].Reason)
+	assert.Equal(t, "root/child/child_scenario", interrupted[0].Detail)
+	runEnd := findEvents[*event.RunEndEvent](sink.Events)
+	assert.Equal(t, "fail", runEnd[0].Status)
+}
+
+func tickingClock(start time.Time, step time.Duration) func() time.Time {
+	current := start
+	return func() time.Time {
+		current = current.Add(step)
+		return current
+	}
+}
+
+func runTimedSpec(t *testing.T, specTree *tree.SpecTree) *SpySink {
+	sink := &SpySink{}
+	runner := NewRunner(&fakeexec.FakeExecutor{}, sink)
+	runner.now = tickingClock(time.Date(2026, 1, 15, 14, 30, 0, 0, time.UTC), 100*time.Millisecond)
+
+	err := runner.RunWithID("test-run", specTree, absSpecPath(specTree))
+	require.NoError(t, err)
+
+	return sink
+}
+
+func TestRunner_EndEventsCarryPhaseDurations(t *testing.T) {
+	specTree := withAssertions(withBeforeEachHook(newSpecTree("root"), "setup"), "check")
+
+	sink := runTimedSpec(t, specTree)
+
+	hookEnds := findEvents[*event.HookEndEvent](sink.Events)
+	require.Len(t, hookEnds, 1)
+	assert.Equal(t, int64(100), hookEnds[0].DurationMs)
+	runEnds := findEvents[*event.ScenarioRunEndEvent](sink.Events)
+	require.Len(t, runEnds, 1)
+	assert.Equal(t, int64(100), runEnds[0].DurationMs)
+	assertionEnds := findEvents[*event.AssertionEndEvent](sink.Events)
+	require.Len(t, assertionEnds, 1)
+	assert.Equal(t, int64(100), assertionEnds[0].DurationMs)
+}
+
+func TestRunner_ScenarioContextAndRunDurationsSpanTheirContents(t *testing.T) {
+	specTree := withAssertions(withBeforeEachHook(newSpecTree("root"), "setup"), "check")
+
+	sink := runTimedSpec(t, specTree)
+
+	exits := findEvents[*event.ScenarioExitEvent](sink.Events)
+	require.Len(t, exits, 1)
+	assert.Equal(t, int64(700), exits[0].DurationMs)
+	contextExits := findEvents[*event.ContextExitEvent](sink.Events)
+	require.Len(t, contextExits, 1)
+	assert.Equal(t, int64(900), contextExits[0].DurationMs)
+	runEnds := findEvents[*event.RunEndEvent](sink.Events)
+	require.Len(t, runEnds, 1)
+	assert.Equal(t, int64(1100), runEnds[0].DurationMs)
+}
+
+func TestRunner_StartEventsCarryTimestamps(t *testing.T) {
+	specTree := withAssertions(withBeforeEachHook(newSpecTree("root"), "setup"), "check")
+
+	sink := runTimedSpec(t, specTree)
+
+	base := time.Date(2026, 1, 15, 14, 30, 0, 0, time.UTC)
+	hookStarts := findEvents[*event.HookStartEvent](sink.Events)
+	require.Len(t, hookStarts, 1)
+	assert.Equal(t, base.Add(400*time.Millisecond), hookStarts[0].Timestamp)
+	runStarts := findEvents[*event.ScenarioRunStartEvent](sink.Events)
+	require.Len(t, runStarts, 1)
+	assert.Equal(t, base.Add(600*time.Millisecond), runStarts[0].Timestamp)
+	assertionStarts := findEvents[*event.AssertionStartEvent](sink.Events)
+	require.Len(t, assertionStarts, 1)
+	assert.Equal(t, base.Add(800*time.Millisecond), assertionStarts[0].Timestamp)
+}
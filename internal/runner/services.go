@@ -0,0 +1,156 @@
+package runner
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	eventpkg "basanos/internal/event"
+	"basanos/internal/executor"
+	"basanos/internal/spec"
+)
+
+const (
+	defaultServiceTimeout = 30 * time.Second
+	defaultProbeInterval  = 100 * time.Millisecond
+	probeTimeout          = time.Second
+)
+
+type runningService struct {
+	name    string
+	process executor.Process
+}
+
+type serviceLog struct {
+	mutex   sync.Mutex
+	content strings.Builder
+}
+
+func (log *serviceLog) append(data string) {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	log.content.WriteString(data)
+}
+
+func (log *serviceLog) matches(pattern *regexp.Regexp) bool {
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	return pattern.MatchString(log.content.String())
+}
+
+func durationOr(value string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+	return duration
+}
+
+func (runner *Runner) startServices(path string, services []spec.Service, env map[string]string) ([]runningService, map[string]string, bool) {
+	var started []runningService
+	for _, service := range services {
+		env = mergeEnv(env, service.Env)
+		process, ready := runner.startService(path, service, env)
+		if !ready {
+			return started, env, false
+		}
+		started = append(started, runningService{name: service.Name, process: process})
+	}
+	return started, env, true
+}
+
+func (runner *Runner) startService(path string, service spec.Service, env map[string]string) (executor.Process, bool) {
+	command := substituteVars(service.Run, env)
+	runner.emit(eventpkg.NewServiceStartEvent(runner.runID, path, service.Name, command, runner.now()))
+
+	logs := &serviceLog{}
+	emitOutput := runner.outputHandler(path, "_services/"+service.Name)
+	process, err := runner.executor.Start(command, env, func(stream, data string) {
+		logs.append(data)
+		emitOutput(stream, data)
+	})
+	if err != nil {
+		runner.emit(eventpkg.NewServiceStopEvent(runner.runID, path, service.Name, "start_failed", -1))
+		return nil, false
+	}
+
+	if reason := runner.awaitReady(service, env, process, logs); reason != "" {
+		exitCode := process.Stop()
+		runner.emit(eventpkg.NewServiceStopEvent(runner.runID, path, service.Name, reason, exitCode))
+		return nil, false
+	}
+	runner.emit(eventpkg.NewServiceReadyEvent(runner.runID, path, service.Name))
+	return process, true
+}
+
+func (runner *Runner) awaitReady(service spec.Service, env map[string]string, process executor.Process, logs *serviceLog) string {
+	if service.Ready == nil {
+		return ""
+	}
+	deadline := runner.now().Add(durationOr(service.Timeout, defaultServiceTimeout))
+	interval := durationOr(service.Ready.Interval, defaultProbeInterval)
+	for {
+		if runner.probe(service.Ready, env, logs) {
+			return ""
+		}
+		select {
+		case <-process.Done():
+			return "exited"
+		default:
+		}
+		if runner.now().After(deadline) {
+			return "startup_timeout"
+		}
+		if runner.isAborted() {
+			return "interrupted"
+		}
+		runner.sleep(interval)
+	}
+}
+
+func (runner *Runner) probe(ready *spec.Readiness, env map[string]string, logs *serviceLog) bool {
+	switch {
+	case ready.TCP != "":
+		return probeTCP(spec.TCPAddress(substituteVars(ready.TCP, env)))
+	case ready.HTTP != "":
+		return probeHTTP(substituteVars(ready.HTTP, env))
+	case ready.Log != "":
+		pattern, err := regexp.Compile(ready.Log)
+		return err == nil && logs.matches(pattern)
+	case ready.Command != "":
+		_, _, exitCode, err := runner.executor.Execute(substituteVars(ready.Command, env), probeTimeout.String(), env)
+		return exitCode == 0 && !errors.Is(err, executor.ErrTimeout)
+	}
+	return true
+}
+
+func probeTCP(address string) bool {
+	connection, err := net.DialTimeout("tcp", address, probeTimeout)
+	if err != nil {
+		return false
+	}
+	connection.Close()
+	return true
+}
+
+func probeHTTP(url string) bool {
+	client := http.Client{Timeout: probeTimeout}
+	response, err := client.Get(url)
+	if err != nil {
+		return false
+	}
+	response.Body.Close()
+	return response.StatusCode < 400
+}
+
+func (runner *Runner) stopServices(path string, services []runningService) {
+	for index := len(services) - 1; index >= 0; index-- {
+		service := services[index]
+		exitCode := service.process.Stop()
+		runner.emit(eventpkg.NewServiceStopEvent(runner.runID, path, service.name, "teardown", exitCode))
+	}
+}
@@ -0,0 +1,132 @@
+package sink
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"basanos/internal/event"
+)
+
+type assertionResult struct {
+	index    int
+	command  string
+	exitCode int
+	attempts int
+	output   strings.Builder
+}
+
+type attemptDetails struct {
+	hookFailure string
+	timeout     string
+	assertions  []*assertionResult
+	stdout      strings.Builder
+	stderr      strings.Builder
+}
+
+func (details *attemptDetails) resetAttempt() {
+	details.hookFailure = ""
+	details.timeout = ""
+	details.assertions = nil
+	details.stdout.Reset()
+	details.stderr.Reset()
+}
+
+func (details *attemptDetails) findAssertion(index int) *assertionResult {
+	for _, assertion := range details.assertions {
+		if assertion.index == index {
+			return assertion
+		}
+	}
+	return nil
+}
+
+func (details *attemptDetails) recordHookFailure(message string) {
+	if details.hookFailure == "" {
+		details.hookFailure = message
+	}
+}
+
+func (details *attemptDetails) recordTimeout(message string) {
+	if details.timeout == "" {
+		details.timeout = message
+	}
+}
+
+func (details *attemptDetails) recordAssertionStart(start *event.AssertionStartEvent) {
+	details.assertions = append(details.assertions, &assertionResult{index: start.Index, command: start.Command})
+}
+
+func (details *attemptDetails) recordAssertionEnd(end *event.AssertionEndEvent) {
+	if assertion := details.findAssertion(end.Index); assertion != nil {
+		assertion.exitCode = end.ExitCode
+		assertion.attempts = end.Attempts
+	}
+}
+
+func (details *attemptDetails) recordOutput(output *event.OutputEvent) {
+	if output.Phase == "_run" {
+		if output.Stream == "stderr" {
+			details.stderr.WriteString(output.Data)
+		} else {
+			details.stdout.WriteString(output.Data)
+		}
+		return
+	}
+	index, found := assertionIndex(output.Phase)
+	if !found || output.Stream != "stdout" {
+		return
+	}
+	if assertion := details.findAssertion(index); assertion != nil {
+		assertion.output.WriteString(output.Data)
+	}
+}
+
+func (details *attemptDetails) failureMessage() string {
+	for _, assertion := range details.assertions {
+		if assertion.exitCode != 0 {
+			return assertion.command
+		}
+	}
+	return "test failed"
+}
+
+func (details *attemptDetails) assertionReport() string {
+	var report strings.Builder
+	for _, assertion := range details.assertions {
+		if report.Len() > 0 {
+			report.WriteString("\n")
+		}
+		fmt.Fprintf(&report, "%s (exit %d%s)\n", assertion.command, assertion.exitCode, attemptsSuffix(assertion.attempts))
+		output := assertion.output.String()
+		report.WriteString(output)
+		if output != "" && !strings.HasSuffix(output, "\n") {
+			report.WriteString("\n")
+		}
+	}
+	return report.String()
+}
+
+func attemptsSuffix(attempts int) string {
+	if attempts <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" after %d attempts", attempts)
+}
+
+func assertionIndex(phase string) (int, bool) {
+	suffix, found := strings.CutPrefix(phase, "_assertions/")
+	if !found {
+		return 0, false
+	}
+	index, err := strconv.Atoi(suffix)
+	return index, err == nil
+}
+
+func hookFailureMessage(hook string, exitCode int) string {
+	return fmt.Sprintf("%s hook exited with code %d", hook, exitCode)
+}
+
+func timeoutMessage(timeout *event.TimeoutEvent) string {
+	return fmt.Sprintf("%s timed out after %s", timeout.Phase, timeout.Limit)
+}
@@ -3,25 +3,31 @@ package cli
 type colorizer interface {
 	green(text string) string
 	red(text string) string
+	yellow(text string) string
 	formatName(name, status string) string
 }
 
 type noopColorizer struct{}
 
-func (noop noopColorizer) green(text string) string { return text }
-func (noop noopColorizer) red(text string) string   { return text }
+func (noop noopColorizer) green(text string) string  { return text }
+func (noop noopColorizer) red(text string) string    { return text }
+func (noop noopColorizer) yellow(text string) string { return text }
 func (noop noopColorizer) formatName(name, status string) string {
 	return name + " " + statusChar(status)
 }
 
 type ansiColorizer struct{}
 
-func (ansi ansiColorizer) green(text string) string { return "\033[32m" + text + "\033[0m" }
-func (ansi ansiColorizer) red(text string) string   { return "\033[31m" + text + "\033[0m" }
+func (ansi ansiColorizer) green(text string) string  { return "\033[32m" + text + "\033[0m" }
+func (ansi ansiColorizer) red(text string) string    { return "\033[31m" + text + "\033[0m" }
+func (ansi ansiColorizer) yellow(text string) string { return "\033[33m" + text + "\033[0m" }
 func (ansi ansiColorizer) formatName(name, status string) string {
 	if status == "pass" {
 		return ansi.green(name)
 	}
+	if status == "skip" || status == "flaky" {
+		return ansi.yellow(name)
+	}
 	return ansi.red(name)
 }
 
@@ -32,6 +38,15 @@ func statusChar(status string) string {
 	if status == "fail" {
 		return "F"
 	}
+	if status == "error" {
+		return "E"
+	}
+	if status == "skip" {
+		return "S"
+	}
+	if status == "flaky" {
+		return "~"
+	}
 	return ""
 }
 
@@ -14,6 +14,10 @@ func TestStatusChar_Fail(t *testing.T) {
 	assert.Equal(t, "F", statusChar("fail"))
 }
 
+func TestStatusChar_Flaky(t *testing.T) {
+	assert.Equal(t, "~", statusChar("flaky"))
+}
+
 func TestStatusChar_Unknown(t *testing.T) {
 	assert.Equal(t, "", statusChar("unknown"))
 }
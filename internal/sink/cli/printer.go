@@ -34,6 +34,15 @@ func (dot *dotPrinter) formatChar(status string) string {
 	if status == "pass" {
 		return dot.color.green(".")
 	}
+	if status == "error" {
+		return dot.color.red("E")
+	}
+	if status == "skip" {
+		return dot.color.yellow("S")
+	}
+	if status == "flaky" {
+		return dot.color.yellow("~")
+	}
 	return dot.color.red("F")
 }
 
@@ -25,6 +25,24 @@ func TestDotPrinter_PrintScenarioResult_Fail(t *testing.T) {
 	assert.Equal(t, "F", buf.String())
 }
 
+func TestDotPrinter_PrintScenarioResult_Error(t *testing.T) {
+	buf := &bytes.Buffer{}
+	dot := &dotPrinter{writer: buf, color: noopColorizer{}}
+
+	dot.printScenarioResult("error")
+
+	assert.Equal(t, "E", buf.String())
+}
+
+func TestDotPrinter_PrintScenarioResult_Flaky(t *testing.T) {
+	buf := &bytes.Buffer{}
+	dot := &dotPrinter{writer: buf, color: ansiColorizer{}}
+
+	dot.printScenarioResult("flaky")
+
+	assert.Equal(t, "\033[33m~\033[0m", buf.String())
+}
+
 func TestDotPrinter_PrintScenarioResult_WithColor(t *testing.T) {
 	buf := &bytes.Buffer{}
 	dot := &dotPrinter{writer: buf, color: ansiColorizer{}}
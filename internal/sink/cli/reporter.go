@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 
 	"basanos/internal/event"
@@ -11,19 +12,39 @@ import (
 
 type failure struct {
 	path   string
+	detail string
 	stdout string
 	stderr string
 }
 
+type flakyScenario struct {
+	path    string
+	attempt int
+}
+
+type serviceOutput struct {
+	stdout strings.Builder
+	stderr strings.Builder
+}
+
 type Reporter struct {
 	writer        io.Writer
 	printer       printer
 	failures      []failure
+	flaky         []flakyScenario
+	leaks         []*event.ProcessLeakEvent
+	snapshots     []*event.SnapshotEvent
+	services      map[string]*serviceOutput
+	interrupted   *event.RunInterruptedEvent
+	timings       *timings
+	slowest       int
+	inScenario    bool
+	hookFailure   string
 	currentStdout strings.Builder
 	currentStderr strings.Builder
 }
 
-func NewReporter(writer io.Writer, verbose bool, color bool) sink.Sink {
+func NewReporter(writer io.Writer, verbose bool, color bool, slowest int) sink.Sink {
 	colors := newColorizer(color)
 	var output printer
 	if verbose {
@@ -31,7 +52,13 @@ func NewReporter(writer io.Writer, verbose bool, color bool) sink.Sink {
 	} else {
 		output = &dotPrinter{writer: writer, color: colors}
 	}
-	return &Reporter{writer: writer, printer: output}
+	return &Reporter{
+		writer:   writer,
+		printer:  output,
+		services: map[string]*serviceOutput{},
+		timings:  newTimings(),
+		slowest:  slowest,
+	}
 }
 
 func (reporter *Reporter) Emit(incoming any) error {
@@ -41,23 +68,82 @@ func (reporter *Reporter) Emit(incoming any) error {
 	case *event.ContextExitEvent:
 		reporter.printer.printContextExit()
 	case *event.ScenarioEnterEvent:
-		reporter.printer.printScenarioEnter(typed.Name)
-		reporter.currentStdout.Reset()
-		reporter.currentStderr.Reset()
+		if typed.Attempt <= 1 {
+			reporter.printer.printScenarioEnter(typed.Name)
+		}
+		reporter.inScenario = true
+		reporter.hookFailure = ""
+		reporter.resetOutput()
+		reporter.timings.enter(typed.Path)
+	case *event.HookStartEvent:
+		if !reporter.inScenario {
+			reporter.resetOutput()
+		}
+	case *event.HookEndEvent:
+		reporter.timings.addPhase(typed.Path, strings.TrimPrefix(typed.Hook, "_"), typed.DurationMs)
+		reporter.handleHookEnd(typed)
+	case *event.ScenarioRunEndEvent:
+		reporter.timings.addPhase(typed.Path, "run", typed.DurationMs)
+	case *event.AssertionEndEvent:
+		reporter.timings.addPhase(typed.Path, "assertions", typed.DurationMs)
 	case *event.OutputEvent:
 		reporter.handleOutput(typed)
+	case *event.ServiceStopEvent:
+		reporter.handleServiceStop(typed)
+	case *event.ProcessLeakEvent:
+		reporter.leaks = append(reporter.leaks, typed)
+	case *event.SnapshotEvent:
+		reporter.snapshots = append(reporter.snapshots, typed)
+	case *event.RunInterruptedEvent:
+		reporter.interrupted = typed
 	case *event.ScenarioExitEvent:
+		reporter.timings.exit(typed.Path, typed.Status, typed.DurationMs)
 		reporter.handleScenarioExit(typed)
+	case *event.ScenarioSkippedEvent:
+		reporter.handleScenarioSkipped(typed)
 	case *event.RunEndEvent:
 		reporter.printer.finish()
 		fmt.Fprintf(reporter.writer, "\n")
 		reporter.printFailures()
-		reporter.printSummary(typed.Passed, typed.Failed)
+		reporter.printFlaky()
+		reporter.printSnapshots()
+		reporter.printSlowest()
+		reporter.printLeaks()
+		reporter.printInterruption()
+		reporter.printSummary(typed)
 	}
 	return nil
 }
 
+func (reporter *Reporter) resetOutput() {
+	reporter.currentStdout.Reset()
+	reporter.currentStderr.Reset()
+}
+
+func (reporter *Reporter) handleHookEnd(end *event.HookEndEvent) {
+	if end.ExitCode == 0 {
+		return
+	}
+	detail := fmt.Sprintf("%s hook exited with code %d", end.Hook, end.ExitCode)
+	if reporter.inScenario {
+		if reporter.hookFailure == "" {
+			reporter.hookFailure = detail
+		}
+		return
+	}
+	reporter.failures = append(reporter.failures, failure{
+		path:   end.Path,
+		detail: detail,
+		stdout: reporter.currentStdout.String(),
+		stderr: reporter.currentStderr.String(),
+	})
+}
+
 func (reporter *Reporter) handleOutput(output *event.OutputEvent) {
+	if strings.HasPrefix(output.Phase, "_services/") {
+		reporter.handleServiceOutput(output)
+		return
+	}
 	switch output.Stream {
 	case "stdout":
 		reporter.currentStdout.WriteString(output.Data)
@@ -66,17 +152,86 @@ func (reporter *Reporter) handleOutput(output *event.OutputEvent) {
 	}
 }
 
+func (reporter *Reporter) handleServiceOutput(output *event.OutputEvent) {
+	key := output.Path + "/" + output.Phase
+	logs, ok := reporter.services[key]
+	if !ok {
+		logs = &serviceOutput{}
+		reporter.services[key] = logs
+	}
+	switch output.Stream {
+	case "stdout":
+		logs.stdout.WriteString(output.Data)
+	case "stderr":
+		logs.stderr.WriteString(output.Data)
+	}
+}
+
+func (reporter *Reporter) handleServiceStop(stop *event.ServiceStopEvent) {
+	key := stop.Path + "/_services/" + stop.Service
+	logs, ok := reporter.services[key]
+	delete(reporter.services, key)
+	if stop.Reason == "teardown" || stop.Reason == "interrupted" {
+		return
+	}
+	fail := failure{path: stop.Path, detail: serviceFailureDetail(stop)}
+	if ok {
+		fail.stdout = logs.stdout.String()
+		fail.stderr = logs.stderr.String()
+	}
+	reporter.failures = append(reporter.failures, fail)
+}
+
+func serviceFailureDetail(stop *event.ServiceStopEvent) string {
+	switch stop.Reason {
+	case "exited":
+		return fmt.Sprintf("service %s exited with code %d before it was ready", stop.Service, stop.ExitCode)
+	case "startup_timeout":
+		return fmt.Sprintf("service %s was not ready before its startup timeout", stop.Service)
+	default:
+		return fmt.Sprintf("service %s could not be started", stop.Service)
+	}
+}
+
 func (reporter *Reporter) handleScenarioExit(exit *event.ScenarioExitEvent) {
+	reporter.inScenario = false
+	if exit.Status == "retry" {
+		return
+	}
 	reporter.printer.printScenarioResult(exit.Status)
-	if exit.Status == "fail" {
+	if exit.Status == "flaky" {
+		reporter.flaky = append(reporter.flaky, flakyScenario{path: exit.Path, attempt: exit.Attempt})
+		return
+	}
+	if exit.Status != "pass" {
 		reporter.failures = append(reporter.failures, failure{
 			path:   exit.Path,
+			detail: failureDetail(reporter.hookFailure, exit.Attempt),
 			stdout: reporter.currentStdout.String(),
 			stderr: reporter.currentStderr.String(),
 		})
 	}
 }
 
+func failureDetail(hookFailure string, attempt int) string {
+	if attempt <= 1 {
+		return hookFailure
+	}
+	attempts := fmt.Sprintf("after %d attempts", attempt)
+	if hookFailure == "" {
+		return attempts
+	}
+	return hookFailure + ", " + attempts
+}
+
+func (reporter *Reporter) handleScenarioSkipped(skipped *event.ScenarioSkippedEvent) {
+	if skipped.Reason == "filter" {
+		return
+	}
+	reporter.printer.printScenarioEnter(skipped.Name)
+	reporter.printer.printScenarioResult("skip")
+}
+
 func (reporter *Reporter) printFailures() {
 	if len(reporter.failures) == 0 {
 		return
@@ -89,7 +244,11 @@ func (reporter *Reporter) printFailures() {
 }
 
 func (reporter *Reporter) printFailure(index int, fail failure) {
-	fmt.Fprintf(reporter.writer, "  %d) %s\n", index, fail.path)
+	if fail.detail != "" {
+		fmt.Fprintf(reporter.writer, "  %d) %s (%s)\n", index, fail.path, fail.detail)
+	} else {
+		fmt.Fprintf(reporter.writer, "  %d) %s\n", index, fail.path)
+	}
 	reporter.printIndentedOutput("stdout", fail.stdout)
 	reporter.printIndentedOutput("stderr", fail.stderr)
 }
@@ -104,6 +263,91 @@ func (reporter *Reporter) printIndentedOutput(label, content string) {
 	}
 }
 
-func (reporter *Reporter) printSummary(passed, failed int) {
-	fmt.Fprintf(reporter.writer, "%d passed, %d failed\n", passed, failed)
+func (reporter *Reporter) printFlaky() {
+	if len(reporter.flaky) == 0 {
+		return
+	}
+	fmt.Fprintf(reporter.writer, "Flaky:\n\n")
+	for index, flaky := range reporter.flaky {
+		fmt.Fprintf(reporter.writer, "  %d) %s (passed on attempt %d)\n", index+1, flaky.path, flaky.attempt)
+	}
+	fmt.Fprintf(reporter.writer, "\n")
+}
+
+func (reporter *Reporter) printSnapshots() {
+	if len(reporter.snapshots) == 0 {
+		return
+	}
+	fmt.Fprintf(reporter.writer, "Snapshots:\n\n")
+	stale := false
+	for index, snapshot := range reporter.snapshots {
+		fmt.Fprintf(reporter.writer, "  %d) %s %s: %s\n", index+1, snapshot.Status, snapshot.Path, snapshot.File)
+		stale = stale || snapshot.Status == "stale"
+	}
+	if stale {
+		fmt.Fprintf(reporter.writer, "\n  Run with --update-snapshots to accept stale snapshots.\n")
+	}
+	fmt.Fprintf(reporter.writer, "\n")
+}
+
+func (reporter *Reporter) printSlowest() {
+	if reporter.slowest <= 0 {
+		return
+	}
+	slowest := reporter.timings.slowest(reporter.slowest)
+	if len(slowest) == 0 {
+		return
+	}
+	fmt.Fprintf(reporter.writer, "Slowest:\n\n")
+	for index, timing := range slowest {
+		fmt.Fprintf(reporter.writer, "  %d) %s\n", index+1, timing.describe())
+	}
+	fmt.Fprintf(reporter.writer, "\n")
+}
+
+func (reporter *Reporter) printLeaks() {
+	if len(reporter.leaks) == 0 {
+		return
+	}
+	fmt.Fprintf(reporter.writer, "Leaked processes:\n\n")
+	for index, leak := range reporter.leaks {
+		fmt.Fprintf(reporter.writer, "  %d) %s (%s): %s\n", index+1, leak.Path, leak.Phase, describePIDs(leak))
+	}
+	fmt.Fprintf(reporter.writer, "\n")
+}
+
+func describePIDs(leak *event.ProcessLeakEvent) string {
+	if len(leak.PIDs) == 0 {
+		return fmt.Sprintf("process group %d still running", leak.PGID)
+	}
+	pids := make([]string, len(leak.PIDs))
+	for index, pid := range leak.PIDs {
+		pids[index] = strconv.Itoa(pid)
+	}
+	return fmt.Sprintf("pids %s still running", strings.Join(pids, ", "))
+}
+
+func (reporter *Reporter) printInterruption() {
+	if reporter.interrupted == nil {
+		return
+	}
+	switch reporter.interrupted.Reason {
+	case "abort_run":
+		fmt.Fprintf(reporter.writer, "Run aborted after %s failed\n\n", reporter.interrupted.Detail)
+	case "panic":
+		fmt.Fprintf(reporter.writer, "Run interrupted by panic: %s\n\n", reporter.interrupted.Detail)
+	default:
+		fmt.Fprintf(reporter.writer, "Run interrupted by %s\n\n", reporter.interrupted.Detail)
+	}
+}
+
+func (reporter *Reporter) printSummary(end *event.RunEndEvent) {
+	summary := fmt.Sprintf("%d passed, %d failed", end.Passed, end.Failed)
+	if end.Skipped > 0 {
+		summary += fmt.Sprintf(", %d skipped", end.Skipped)
+	}
+	if end.Flaky > 0 {
+		summary += fmt.Sprintf(", %d flaky", end.Flaky)
+	}
+	fmt.Fprintf(reporter.writer, "%s\n", summary)
 }
@@ -12,23 +12,23 @@ import (
 
 func TestSink_PrintsSummaryOnRunEnd(t *testing.T) {
 	buffer := &bytes.Buffer{}
-	sink := NewReporter(buffer, false, false)
+	sink := NewReporter(buffer, false, false, 0)
 
 	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
-	sink.Emit(event.NewRunEndEvent("run-1", "fail", 3, 1, timestamp))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 3, 1, 0, 0, timestamp, 0))
 
 	assert.Equal(t, "\n\n3 passed, 1 failed\n", buffer.String())
 }
 
 func TestSink_PrintsFailuresBeforeSummary(t *testing.T) {
 	buffer := &bytes.Buffer{}
-	sink := NewReporter(buffer, false, false)
+	sink := NewReporter(buffer, false, false, 0)
 
 	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
-	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/health", "pass", timestamp))
-	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/login", "fail", timestamp))
-	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/status", "pass", timestamp))
-	sink.Emit(event.NewRunEndEvent("run-1", "fail", 2, 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/health", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/login", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/status", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 2, 1, 0, 0, timestamp, 0))
 
 	expected := `.F.
 
@@ -43,15 +43,15 @@ Failures:
 
 func TestSink_DisplaysStdoutForFailedScenario(t *testing.T) {
 	buffer := &bytes.Buffer{}
-	sink := NewReporter(buffer, false, false)
+	sink := NewReporter(buffer, false, false, 0)
 
 	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
-	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/health", "Health Check", timestamp))
-	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/health", "pass", timestamp))
-	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/login", "Login", timestamp))
-	sink.Emit(event.NewOutputEvent("run-1", "stdout", "Login failed\n"))
-	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/login", "fail", timestamp))
-	sink.Emit(event.NewRunEndEvent("run-1", "fail", 1, 1, timestamp))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/health", "Health Check", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/health", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/login", "Login", 1, timestamp))
+	sink.Emit(event.NewOutputEvent("run-1", "", "", 1, "stdout", "Login failed\n"))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/login", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 1, 1, 0, 0, timestamp, 0))
 
 	expected := `.F
 
@@ -68,14 +68,14 @@ Failures:
 
 func TestSink_DisplaysStderrForFailedScenario(t *testing.T) {
 	buffer := &bytes.Buffer{}
-	sink := NewReporter(buffer, false, false)
+	sink := NewReporter(buffer, false, false, 0)
 
 	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
-	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/error", "Error Test", timestamp))
-	sink.Emit(event.NewOutputEvent("run-1", "stdout", "Attempting request\n"))
-	sink.Emit(event.NewOutputEvent("run-1", "stderr", "Connection refused\n"))
-	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/error", "fail", timestamp))
-	sink.Emit(event.NewRunEndEvent("run-1", "fail", 0, 1, timestamp))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/error", "Error Test", 1, timestamp))
+	sink.Emit(event.NewOutputEvent("run-1", "", "", 1, "stdout", "Attempting request\n"))
+	sink.Emit(event.NewOutputEvent("run-1", "", "", 2, "stderr", "Connection refused\n"))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/error", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 0, 1, 0, 0, timestamp, 0))
 
 	expected := `F
 
@@ -94,16 +94,16 @@ Failures:
 
 func TestSink_FullVerboseRun(t *testing.T) {
 	buffer := &bytes.Buffer{}
-	sink := NewReporter(buffer, true, false)
+	sink := NewReporter(buffer, true, false, 0)
 
 	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
 	sink.Emit(event.NewContextEnterEvent("run-1", "basic_http", "Basic HTTP", timestamp))
 	sink.Emit(event.NewContextEnterEvent("run-1", "basic_http/user_sessions", "User Sessions", timestamp))
-	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/user_sessions/login", "Login works", timestamp))
-	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/user_sessions/login", "pass", timestamp))
-	sink.Emit(event.NewContextExitEvent("run-1", "basic_http/user_sessions", timestamp))
-	sink.Emit(event.NewContextExitEvent("run-1", "basic_http", timestamp))
-	sink.Emit(event.NewRunEndEvent("run-1", "pass", 1, 0, timestamp))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/user_sessions/login", "Login works", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/user_sessions/login", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent("run-1", "basic_http/user_sessions", timestamp, 0))
+	sink.Emit(event.NewContextExitEvent("run-1", "basic_http", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "pass", 1, 0, 0, 0, timestamp, 0))
 
 	expected := `Basic HTTP
   User Sessions
@@ -116,17 +116,307 @@ func TestSink_FullVerboseRun(t *testing.T) {
 
 func TestSink_FullColorRun(t *testing.T) {
 	buffer := &bytes.Buffer{}
-	sink := NewReporter(buffer, true, true)
+	sink := NewReporter(buffer, true, true, 0)
 
 	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
 	sink.Emit(event.NewContextEnterEvent("run-1", "parent", "Parent", timestamp))
-	sink.Emit(event.NewScenarioEnterEvent("run-1", "parent/pass", "Passes", timestamp))
-	sink.Emit(event.NewScenarioExitEvent("run-1", "parent/pass", "pass", timestamp))
-	sink.Emit(event.NewScenarioEnterEvent("run-1", "parent/fail", "Fails", timestamp))
-	sink.Emit(event.NewScenarioExitEvent("run-1", "parent/fail", "fail", timestamp))
-	sink.Emit(event.NewContextExitEvent("run-1", "parent", timestamp))
-	sink.Emit(event.NewRunEndEvent("run-1", "fail", 1, 1, timestamp))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "parent/pass", "Passes", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "parent/pass", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "parent/fail", "Fails", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "parent/fail", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent("run-1", "parent", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 1, 1, 0, 0, timestamp, 0))
 
 	assert.Contains(t, buffer.String(), "\033[32mPasses\033[0m")
 	assert.Contains(t, buffer.String(), "\033[31mFails\033[0m")
 }
+
+func TestSink_ShowsHookFailureForErroredScenario(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/login", "Login", 1, timestamp))
+	sink.Emit(event.NewHookStartEvent("run-1", "basic_http/login", "_before_each", "", time.Time{}))
+	sink.Emit(event.NewOutputEvent("run-1", "", "", 1, "stderr", "database unavailable\n"))
+	sink.Emit(event.NewHookEndEvent("run-1", "basic_http/login", "_before_each", "", 1, 0))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/login", "error", 1, timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 0, 1, 0, 0, timestamp, 0))
+
+	expected := `E
+
+Failures:
+
+  1) basic_http/login (_before_each hook exited with code 1)
+     stderr:
+       database unavailable
+
+0 passed, 1 failed
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_ListsFailedContextHook(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewContextEnterEvent("run-1", "basic_http", "Basic HTTP", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "basic_http/health", "Health", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/health", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewHookStartEvent("run-1", "basic_http", "_after", "", time.Time{}))
+	sink.Emit(event.NewOutputEvent("run-1", "", "", 1, "stdout", "server already stopped\n"))
+	sink.Emit(event.NewHookEndEvent("run-1", "basic_http", "_after", "", 2, 0))
+	sink.Emit(event.NewContextExitEvent("run-1", "basic_http", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 1, 0, 0, 0, timestamp, 0))
+
+	expected := `.
+
+Failures:
+
+  1) basic_http (_after hook exited with code 2)
+     stdout:
+       server already stopped
+
+1 passed, 0 failed
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_PrintsSlowestScenariosWithPhaseBreakdown(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 2)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/health", "Health", 1, timestamp))
+	sink.Emit(event.NewScenarioRunEndEvent("run-1", "api/health", 0, 100*time.Millisecond))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/health", "pass", 1, timestamp, 120*time.Millisecond))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewHookEndEvent("run-1", "api/login", "_before_each", "", 0, 2000*time.Millisecond))
+	sink.Emit(event.NewScenarioRunEndEvent("run-1", "api/login", 0, 250*time.Millisecond))
+	sink.Emit(event.NewAssertionEndEvent("run-1", "api/login", 0, 0, 1, "", 60*time.Millisecond))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/login", "pass", 1, timestamp, 2310*time.Millisecond))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/logout", "Logout", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/logout", "pass", 1, timestamp, 10*time.Millisecond))
+	sink.Emit(event.NewRunEndEvent("run-1", "pass", 3, 0, 0, 0, timestamp, 2500*time.Millisecond))
+
+	expected := `...
+
+Slowest:
+
+  1) api/login 2.31s (before_each 2.00s, run 0.25s, assertions 0.06s)
+  2) api/health 0.12s (run 0.10s)
+
+3 passed, 0 failed
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_OmitsSlowestWhenNotRequested(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/health", "Health", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/health", "pass", 1, timestamp, 120*time.Millisecond))
+	sink.Emit(event.NewRunEndEvent("run-1", "pass", 1, 0, 0, 0, timestamp, 120*time.Millisecond))
+
+	assert.NotContains(t, buffer.String(), "Slowest")
+}
+
+func TestSink_PrintsSkippedScenariosAndCount(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioExitEvent("run-1", "basic_http/health", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioSkippedEvent("run-1", "basic_http/login", "Login", "skip_children", timestamp))
+	sink.Emit(event.NewRunEndEvent("run-1", "pass", 1, 0, 1, 0, timestamp, 0))
+
+	assert.Equal(t, ".S\n\n1 passed, 0 failed, 1 skipped\n", buffer.String())
+}
+
+func TestSink_DoesNotPrintFilteredScenarios(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, true, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioSkippedEvent("run-1", "basic_http/login", "Login", "filter", timestamp))
+	sink.Emit(event.NewScenarioSkippedEvent("run-1", "basic_http/logout", "Logout", "abort_run", timestamp))
+	sink.Emit(event.NewRunEndEvent("run-1", "pass", 0, 0, 2, 0, timestamp, 0))
+
+	assert.Equal(t, "Logout S\n\n0 passed, 0 failed, 2 skipped\n", buffer.String())
+}
+
+func TestSink_PrintsFlakyScenarioOnceWithAttempt(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, true, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "agents/plan", "Plans", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "agents/plan", "retry", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "agents/plan", "Plans", 2, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "agents/plan", "flaky", 2, timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "pass", 1, 0, 0, 1, timestamp, 0))
+
+	expected := `Plans ~
+
+Flaky:
+
+  1) agents/plan (passed on attempt 2)
+
+1 passed, 0 failed, 1 flaky
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_SummarizesNewAndStaleSnapshots(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewSnapshotEvent("run-1", "api/login", 0, "spec/__snapshots__/login.snap", "new"))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/login", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/logout", "Logout", 1, timestamp))
+	sink.Emit(event.NewSnapshotEvent("run-1", "api/logout", 0, "spec/__snapshots__/logout.snap", "stale"))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/logout", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 1, 1, 0, 0, timestamp, 0))
+
+	assert.Contains(t, buffer.String(), `Snapshots:
+
+  1) new api/login: spec/__snapshots__/login.snap
+  2) stale api/logout: spec/__snapshots__/logout.snap
+
+  Run with --update-snapshots to accept stale snapshots.
+
+1 passed, 1 failed
+`)
+}
+
+func TestSink_PrintsLeakedProcesses(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewProcessLeakEvent("run-1", "api", "_before", 4242, []int{4242, 4250}))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/login", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "pass", 1, 0, 0, 0, timestamp, 0))
+
+	expected := `.
+
+Leaked processes:
+
+  1) api (_before): pids 4242, 4250 still running
+
+1 passed, 0 failed
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_ReportsAttemptsForScenarioFailingAfterRetries(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "agents/plan", "Plans", 1, timestamp))
+	sink.Emit(event.NewOutputEvent("run-1", "", "", 1, "stdout", "first try\n"))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "agents/plan", "retry", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "agents/plan", "Plans", 2, timestamp))
+	sink.Emit(event.NewOutputEvent("run-1", "", "", 2, "stdout", "second try\n"))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "agents/plan", "fail", 2, timestamp, 0))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 0, 1, 0, 0, timestamp, 0))
+
+	expected := `F
+
+Failures:
+
+  1) agents/plan (after 2 attempts)
+     stdout:
+       second try
+
+0 passed, 1 failed
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_PrintsServiceFailureWithLogs(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewServiceStartEvent("run-1", "api", "db", "start_db", time.Time{}))
+	sink.Emit(event.NewOutputEvent("run-1", "api", "_services/db", 1, "stderr", "port in use\n"))
+	sink.Emit(event.NewServiceStopEvent("run-1", "api", "db", "exited", 1))
+	sink.Emit(event.NewScenarioSkippedEvent("run-1", "api/login", "Login", "service_failure", timestamp))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 0, 0, 1, 0, timestamp, 0))
+
+	expected := `S
+
+Failures:
+
+  1) api (service db exited with code 1 before it was ready)
+     stderr:
+       port in use
+
+0 passed, 0 failed, 1 skipped
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_IgnoresServiceOutputInScenarioFailures(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewOutputEvent("run-1", "api", "_services/db", 1, "stdout", "query ok\n"))
+	sink.Emit(event.NewOutputEvent("run-1", "api/login", "_run", 2, "stdout", "401\n"))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/login", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewServiceStopEvent("run-1", "api", "db", "teardown", -1))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 0, 1, 0, 0, timestamp, 0))
+
+	expected := `F
+
+Failures:
+
+  1) api/login
+     stdout:
+       401
+
+0 passed, 1 failed
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_PrintsInterruptionBeforeSummary(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioEnterEvent("run-1", "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent("run-1", "api/login", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewRunInterruptedEvent("run-1", "signal", "interrupt", timestamp))
+	sink.Emit(event.NewScenarioSkippedEvent("run-1", "api/logout", "Logout", "interrupted", timestamp))
+	sink.Emit(event.NewRunEndEvent("run-1", "interrupted", 1, 0, 1, 0, timestamp, 0))
+
+	expected := `.S
+
+Run interrupted by interrupt
+
+1 passed, 0 failed, 1 skipped
+`
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestSink_PrintsAbortingScenario(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewReporter(buffer, false, false, 0)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewRunInterruptedEvent("run-1", "abort_run", "api/login", timestamp))
+	sink.Emit(event.NewRunEndEvent("run-1", "fail", 0, 0, 0, 0, timestamp, 0))
+
+	assert.Equal(t, "\n\nRun aborted after api/login failed\n\n0 passed, 0 failed\n", buffer.String())
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type phaseTiming struct {
+	name       string
+	durationMs int64
+}
+
+type scenarioTiming struct {
+	path       string
+	durationMs int64
+	phases     []phaseTiming
+}
+
+type timings struct {
+	running  map[string]*scenarioTiming
+	finished []*scenarioTiming
+}
+
+func newTimings() *timings {
+	return &timings{running: map[string]*scenarioTiming{}}
+}
+
+func (timings *timings) enter(path string) {
+	if _, exists := timings.running[path]; !exists {
+		timings.running[path] = &scenarioTiming{path: path}
+	}
+}
+
+func (timings *timings) addPhase(path, phase string, durationMs int64) {
+	timing, exists := timings.running[path]
+	if !exists {
+		return
+	}
+	for index := range timing.phases {
+		if timing.phases[index].name == phase {
+			timing.phases[index].durationMs += durationMs
+			return
+		}
+	}
+	timing.phases = append(timing.phases, phaseTiming{name: phase, durationMs: durationMs})
+}
+
+func (timings *timings) exit(path, status string, durationMs int64) {
+	timing, exists := timings.running[path]
+	if !exists {
+		return
+	}
+	timing.durationMs += durationMs
+	if status == "retry" {
+		return
+	}
+	timings.finished = append(timings.finished, timing)
+	delete(timings.running, path)
+}
+
+func (timings *timings) slowest(count int) []*scenarioTiming {
+	sorted := slices.Clone(timings.finished)
+	slices.SortStableFunc(sorted, func(first, second *scenarioTiming) int {
+		return int(second.durationMs - first.durationMs)
+	})
+	return sorted[:min(count, len(sorted))]
+}
+
+func (timing *scenarioTiming) describe() string {
+	description := fmt.Sprintf("%s %s", timing.path, formatDuration(timing.durationMs))
+	if len(timing.phases) == 0 {
+		return description
+	}
+	phases := make([]string, len(timing.phases))
+	for index, phase := range timing.phases {
+		phases[index] = fmt.Sprintf("%s %s", phase.name, formatDuration(phase.durationMs))
+	}
+	return fmt.Sprintf("%s (%s)", description, strings.Join(phases, ", "))
+}
+
+func formatDuration(durationMs int64) string {
+	return fmt.Sprintf("%.2fs", float64(durationMs)/1000)
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimings_SlowestOrdersByDuration(t *testing.T) {
+	timings := newTimings()
+	timings.enter("api/fast")
+	timings.exit("api/fast", "pass", 100)
+	timings.enter("api/slow")
+	timings.exit("api/slow", "fail", 2500)
+	timings.enter("api/medium")
+	timings.exit("api/medium", "pass", 800)
+
+	slowest := timings.slowest(2)
+
+	require.Len(t, slowest, 2)
+	assert.Equal(t, "api/slow", slowest[0].path)
+	assert.Equal(t, "api/medium", slowest[1].path)
+}
+
+func TestTimings_SlowestReturnsAllWhenFewerFinished(t *testing.T) {
+	timings := newTimings()
+	timings.enter("api/only")
+	timings.exit("api/only", "pass", 100)
+
+	assert.Len(t, timings.slowest(5), 1)
+}
+
+func TestTimings_SumsPhasesAndAttempts(t *testing.T) {
+	timings := newTimings()
+	timings.enter("api/login")
+	timings.addPhase("api/login", "before_each", 1000)
+	timings.addPhase("api/login", "run", 200)
+	timings.exit("api/login", "retry", 1250)
+	timings.enter("api/login")
+	timings.addPhase("api/login", "before_each", 1000)
+	timings.addPhase("api/login", "run", 300)
+	timings.addPhase("api/login", "assertions", 20)
+	timings.addPhase("api/login", "assertions", 30)
+	timings.exit("api/login", "flaky", 1400)
+
+	slowest := timings.slowest(1)
+
+	require.Len(t, slowest, 1)
+	assert.Equal(t, "api/login 2.65s (before_each 2.00s, run 0.50s, assertions 0.05s)", slowest[0].describe())
+}
+
+func TestTimings_IgnoresPhasesOutsideScenarios(t *testing.T) {
+	timings := newTimings()
+	timings.addPhase("api", "before", 500)
+	timings.exit("api", "pass", 500)
+
+	assert.Empty(t, timings.slowest(3))
+}
@@ -13,10 +13,8 @@ type FileSinkHandler interface {
 }
 
 type FileSink struct {
-	fs           fs.WritableFS
-	runID        string
-	currentPath  string
-	currentPhase string
+	fs    fs.WritableFS
+	runID string
 }
 
 func NewFileSink(filesystem fs.WritableFS, runID string) *FileSink {
@@ -33,29 +31,37 @@ func (sink *FileSink) Emit(incoming any) error {
 	return nil
 }
 
-func (sink *FileSink) SetCurrentPath(path string) {
-	sink.currentPath = path
+func (sink *FileSink) WriteExitCode(path, phase string, code int) error {
+	filePath := filepath.Join(sink.runID, path, phase, "exit_code")
+	return sink.fs.WriteFile(filePath, []byte(strconv.Itoa(code)))
 }
 
-func (sink *FileSink) SetCurrentPhase(phase string) {
-	sink.currentPhase = phase
+func (sink *FileSink) AppendOutput(path, phase, stream, data string) error {
+	filePath := filepath.Join(sink.runID, path, phase, stream)
+	return sink.fs.AppendFile(filePath, []byte(data))
 }
 
-func (sink *FileSink) WriteExitCode(path, phase string, code int) error {
-	filePath := filepath.Join(sink.runID, path, phase, "exit_code")
-	return sink.fs.WriteFile(filePath, []byte(strconv.Itoa(code)))
+func (sink *FileSink) ReplaceOutput(path, phase, stream, data string) error {
+	filePath := filepath.Join(sink.runID, path, phase, stream)
+	raw, err := sink.fs.ReadFile(filePath)
+	if err != nil {
+		raw = []byte{}
+	}
+	if err := sink.fs.WriteFile(filepath.Join(sink.runID, path, phase, "raw", stream), raw); err != nil {
+		return err
+	}
+	return sink.fs.WriteFile(filePath, []byte(data))
 }
 
-func (sink *FileSink) AppendOutput(stream, data string) error {
-	path := filepath.Join(sink.runID, sink.currentPath, sink.currentPhase, stream)
-	return sink.fs.AppendFile(path, []byte(data))
+func (sink *FileSink) ClearOutput(path string) error {
+	return sink.fs.RemoveAll(filepath.Join(sink.runID, path))
 }
 
-func (sink *FileSink) EnsureOutput(stream string) error {
-	path := filepath.Join(sink.runID, sink.currentPath, sink.currentPhase, stream)
-	_, err := sink.fs.ReadFile(path)
+func (sink *FileSink) EnsureOutput(path, phase, stream string) error {
+	filePath := filepath.Join(sink.runID, path, phase, stream)
+	_, err := sink.fs.ReadFile(filePath)
 	if err != nil {
-		return sink.fs.WriteFile(path, []byte{})
+		return sink.fs.WriteFile(filePath, []byte{})
 	}
 	return nil
 }
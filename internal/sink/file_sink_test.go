@@ -2,6 +2,7 @@ package sink
 
 import (
 	"testing"
+	"time"
 
 	"basanos/internal/event"
 	"basanos/internal/testutil/fs"
@@ -14,22 +15,50 @@ func TestFileSink_WritesOutput(t *testing.T) {
 	memFS := fs.NewMemoryFS()
 	sink := NewFileSink(memFS, "2026-01-15_143022")
 
-	sink.Emit(event.NewScenarioRunStartEvent("2026-01-15_143022", "basic_http/login"))
-	sink.Emit(event.NewOutputEvent("2026-01-15_143022", "stdout", "hello\n"))
+	sink.Emit(event.NewOutputEvent("2026-01-15_143022", "basic_http/login", "_run", 1, "stdout", "hello\n"))
 
 	content, err := memFS.ReadFile("2026-01-15_143022/basic_http/login/_run/stdout")
 	require.NoError(t, err)
 	assert.Equal(t, "hello\n", string(content))
 }
 
+func TestFileSink_RoutesInterleavedOutputByEvent(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	runID := "2026-01-15_143022"
+	sink := NewFileSink(memFS, runID)
+
+	sink.Emit(event.NewScenarioRunStartEvent(runID, "api/login", time.Time{}))
+	sink.Emit(event.NewScenarioRunStartEvent(runID, "api/logout", time.Time{}))
+	sink.Emit(event.NewOutputEvent(runID, "api/login", "_run", 1, "stdout", "logged in\n"))
+	sink.Emit(event.NewOutputEvent(runID, "api", "_services/db", 2, "stdout", "db listening\n"))
+	sink.Emit(event.NewOutputEvent(runID, "api/logout", "_run", 3, "stdout", "logged out\n"))
+	sink.Emit(event.NewServiceStopEvent(runID, "api", "db", "teardown", 0))
+
+	loginContent, err := memFS.ReadFile(runID + "/api/login/_run/stdout")
+	require.NoError(t, err)
+	assert.Equal(t, "logged in\n", string(loginContent))
+
+	logoutContent, err := memFS.ReadFile(runID + "/api/logout/_run/stdout")
+	require.NoError(t, err)
+	assert.Equal(t, "logged out\n", string(logoutContent))
+
+	serviceContent, err := memFS.ReadFile(runID + "/api/_services/db/stdout")
+	require.NoError(t, err)
+	assert.Equal(t, "db listening\n", string(serviceContent))
+
+	exitCodeContent, err := memFS.ReadFile(runID + "/api/_services/db/exit_code")
+	require.NoError(t, err)
+	assert.Equal(t, "0", string(exitCodeContent))
+}
+
 func TestFileSink_WritesHookOutput(t *testing.T) {
 	memFS := fs.NewMemoryFS()
 	runID := "2026-01-15_143022"
 	sink := NewFileSink(memFS, runID)
 
-	sink.Emit(event.NewHookStartEvent(runID, "basic_http", "before", ""))
-	sink.Emit(event.NewOutputEvent(runID, "stdout", "starting server\n"))
-	sink.Emit(event.NewHookEndEvent(runID, "basic_http", "before", "", 0))
+	sink.Emit(event.NewHookStartEvent(runID, "basic_http", "before", "", time.Time{}))
+	sink.Emit(event.NewOutputEvent(runID, "basic_http", "before", 1, "stdout", "starting server\n"))
+	sink.Emit(event.NewHookEndEvent(runID, "basic_http", "before", "", 0, 0))
 
 	stdoutContent, err := memFS.ReadFile(runID + "/basic_http/before/stdout")
 	require.NoError(t, err)
@@ -45,9 +74,9 @@ func TestFileSink_WritesAssertionOutput(t *testing.T) {
 	runID := "2026-01-15_143022"
 	sink := NewFileSink(memFS, runID)
 
-	sink.Emit(event.NewAssertionStartEvent(runID, "basic_http/login", 0, "assert_equals 0 exit_code"))
-	sink.Emit(event.NewOutputEvent(runID, "stdout", "PASS\n"))
-	sink.Emit(event.NewAssertionEndEvent(runID, "basic_http/login", 0, 0))
+	sink.Emit(event.NewAssertionStartEvent(runID, "basic_http/login", 0, "assert_equals 0 exit_code", time.Time{}))
+	sink.Emit(event.NewOutputEvent(runID, "basic_http/login", "_assertions/0", 1, "stdout", "PASS\n"))
+	sink.Emit(event.NewAssertionEndEvent(runID, "basic_http/login", 0, 0, 1, "", 0))
 
 	stdoutContent, err := memFS.ReadFile(runID + "/basic_http/login/_assertions/0/stdout")
 	require.NoError(t, err)
@@ -63,37 +92,72 @@ func TestFileSink_WritesScenarioRunExitCode(t *testing.T) {
 	runID := "2026-01-15_143022"
 	sink := NewFileSink(memFS, runID)
 
-	sink.Emit(event.NewScenarioRunStartEvent(runID, "basic_http/login"))
-	sink.Emit(event.NewScenarioRunEndEvent(runID, "basic_http/login", 0))
+	sink.Emit(event.NewScenarioRunStartEvent(runID, "basic_http/login", time.Time{}))
+	sink.Emit(event.NewScenarioRunEndEvent(runID, "basic_http/login", 0, 0))
 
 	exitCodeContent, err := memFS.ReadFile(runID + "/basic_http/login/_run/exit_code")
 	require.NoError(t, err)
 	assert.Equal(t, "0", string(exitCodeContent))
 }
 
+func TestFileSink_CreatesEmptyRunOutputForSilentCommands(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	runID := "2026-01-15_143022"
+	sink := NewFileSink(memFS, runID)
+
+	sink.Emit(event.NewOutputEvent(runID, "basic_http/login", "_run", 1, "stdout", "hello\n"))
+	sink.Emit(event.NewScenarioRunEndEvent(runID, "basic_http/login", 0, 0))
+
+	stdoutContent, err := memFS.ReadFile(runID + "/basic_http/login/_run/stdout")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", string(stdoutContent))
+
+	stderrContent, err := memFS.ReadFile(runID + "/basic_http/login/_run/stderr")
+	require.NoError(t, err)
+	assert.Equal(t, "", string(stderrContent))
+}
+
+func TestFileSink_NormalizedOutputReplacesRunOutputAndKeepsRaw(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	runID := "2026-01-15_143022"
+	sink := NewFileSink(memFS, runID)
+
+	sink.Emit(event.NewOutputEvent(runID, "basic_http/login", "_run", 1, "stdout", "at 2026-01-15T14:30:22Z\n"))
+	sink.Emit(event.NewScenarioRunEndEvent(runID, "basic_http/login", 0, 0))
+	sink.Emit(event.NewOutputNormalizedEvent(runID, "basic_http/login", "_run", "stdout", "at <TIMESTAMP>\n"))
+
+	normalized, err := memFS.ReadFile(runID + "/basic_http/login/_run/stdout")
+	require.NoError(t, err)
+	assert.Equal(t, "at <TIMESTAMP>\n", string(normalized))
+	raw, err := memFS.ReadFile(runID + "/basic_http/login/_run/raw/stdout")
+	require.NoError(t, err)
+	assert.Equal(t, "at 2026-01-15T14:30:22Z\n", string(raw))
+}
+
 func TestFileSink_AppendsOutput(t *testing.T) {
 	memFS := fs.NewMemoryFS()
 	runID := "2026-01-15_143022"
 	sink := NewFileSink(memFS, runID)
 
-	sink.Emit(event.NewScenarioRunStartEvent(runID, "basic_http/login"))
-	sink.Emit(event.NewOutputEvent(runID, "stdout", "line1\n"))
-	sink.Emit(event.NewOutputEvent(runID, "stdout", "line2\n"))
+	sink.Emit(event.NewOutputEvent(runID, "basic_http/login", "_run", 1, "stdout", "line1\n"))
+	sink.Emit(event.NewOutputEvent(runID, "basic_http/login", "_run", 2, "stdout", "line2\n"))
 
 	content, err := memFS.ReadFile(runID + "/basic_http/login/_run/stdout")
 	require.NoError(t, err)
 	assert.Equal(t, "line1\nline2\n", string(content))
 }
 
-func TestFileSink_RunDirectoryIsPrefixedWithUnderscore(t *testing.T) {
+func TestFileSink_RetryAttemptReplacesPreviousOutput(t *testing.T) {
 	memFS := fs.NewMemoryFS()
 	runID := "2026-01-15_143022"
 	sink := NewFileSink(memFS, runID)
 
-	sink.Emit(event.NewScenarioRunStartEvent(runID, "basic_http/login"))
-	sink.Emit(event.NewOutputEvent(runID, "stdout", "hello\n"))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "agents/plan", "Plans", 1, time.Now()))
+	sink.Emit(event.NewOutputEvent(runID, "agents/plan", "_run", 1, "stdout", "first attempt\n"))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "agents/plan", "Plans", 2, time.Now()))
+	sink.Emit(event.NewOutputEvent(runID, "agents/plan", "_run", 2, "stdout", "second attempt\n"))
 
-	content, err := memFS.ReadFile(runID + "/basic_http/login/_run/stdout")
+	content, err := memFS.ReadFile(runID + "/agents/plan/_run/stdout")
 	require.NoError(t, err)
-	assert.Equal(t, "hello\n", string(content))
+	assert.Equal(t, "second attempt\n", string(content))
 }
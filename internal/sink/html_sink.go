@@ -0,0 +1,372 @@
+package sink
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path"
+	"strings"
+
+	"basanos/internal/event"
+	"basanos/internal/fs"
+)
+
+type htmlPhase struct {
+	Name       string
+	ExitCode   int
+	DurationMs int64
+	Stdout     strings.Builder
+	Stderr     strings.Builder
+}
+
+type htmlAssertion struct {
+	Index      int
+	Command    string
+	ExitCode   int
+	DurationMs int64
+	Output     strings.Builder
+}
+
+type htmlNode struct {
+	Path       string
+	Name       string
+	Kind       string
+	Status     string
+	Message    string
+	Attempts   int
+	DurationMs int64
+	Setup      []*htmlPhase
+	Run        *htmlPhase
+	Assertions []*htmlAssertion
+	Teardown   []*htmlPhase
+	Children   []*htmlNode
+}
+
+type htmlLine struct {
+	Class string
+	Text  string
+}
+
+type htmlReport struct {
+	RunID      string
+	Status     string
+	Passed     int
+	Failed     int
+	Skipped    int
+	Flaky      int
+	DurationMs int64
+	Roots      []*htmlNode
+}
+
+type HtmlSink struct {
+	fs       fs.WritableFS
+	filename string
+	report   htmlReport
+	nodes    map[string]*htmlNode
+}
+
+func NewHtmlSink(filesystem fs.WritableFS, filename string) Sink {
+	return &HtmlSink{
+		fs:       filesystem,
+		filename: filename,
+		nodes:    make(map[string]*htmlNode),
+	}
+}
+
+func (sink *HtmlSink) Emit(incoming any) error {
+	switch typed := incoming.(type) {
+	case *event.RunStartEvent:
+		sink.report.RunID = typed.RunID
+	case *event.ContextEnterEvent:
+		node := sink.node(typed.Path, "context")
+		node.Name = typed.Name
+	case *event.ContextExitEvent:
+		sink.node(typed.Path, "context").DurationMs = typed.DurationMs
+	case *event.ScenarioEnterEvent:
+		sink.handleScenarioEnter(typed)
+	case *event.ScenarioExitEvent:
+		sink.handleScenarioExit(typed)
+	case *event.ScenarioSkippedEvent:
+		node := sink.node(typed.Path, "scenario")
+		node.Name = typed.Name
+		node.Status = "skipped"
+		node.Message = typed.Reason
+	case *event.HookStartEvent:
+		sink.node(typed.Path, "context").addHook(typed.Hook)
+	case *event.HookEndEvent:
+		sink.handleHookEnd(typed)
+	case *event.ScenarioRunStartEvent:
+		sink.node(typed.Path, "scenario").Run = &htmlPhase{Name: "run"}
+	case *event.ScenarioRunEndEvent:
+		if run := sink.node(typed.Path, "scenario").Run; run != nil {
+			run.ExitCode = typed.ExitCode
+			run.DurationMs = typed.DurationMs
+		}
+	case *event.AssertionStartEvent:
+		node := sink.node(typed.Path, "scenario")
+		node.Assertions = append(node.Assertions, &htmlAssertion{Index: typed.Index, Command: typed.Command})
+	case *event.AssertionEndEvent:
+		if assertion := sink.node(typed.Path, "scenario").findAssertion(typed.Index); assertion != nil {
+			assertion.ExitCode = typed.ExitCode
+			assertion.DurationMs = typed.DurationMs
+		}
+	case *event.OutputEvent:
+		sink.handleOutput(typed)
+	case *event.TimeoutEvent:
+		if node, exists := sink.nodes[typed.Path]; exists && node.Message == "" {
+			node.Message = timeoutMessage(typed)
+		}
+	case *event.ServiceStopEvent:
+		if typed.Reason != "teardown" && typed.Reason != "interrupted" {
+			sink.failContext(typed.Path, fmt.Sprintf("service %s stopped: %s", typed.Service, typed.Reason))
+		}
+	case *event.RunEndEvent:
+		return sink.handleRunEnd(typed)
+	}
+	return nil
+}
+
+func (sink *HtmlSink) node(nodePath, kind string) *htmlNode {
+	if node, exists := sink.nodes[nodePath]; exists {
+		return node
+	}
+	node := &htmlNode{Path: nodePath, Name: path.Base(nodePath), Kind: kind}
+	sink.nodes[nodePath] = node
+	parentPath := path.Dir(nodePath)
+	_, parentExists := sink.nodes[parentPath]
+	if parentPath == nodePath || (!parentExists && !strings.Contains(nodePath, "/")) {
+		sink.report.Roots = append(sink.report.Roots, node)
+		return node
+	}
+	parent := sink.node(parentPath, "group")
+	parent.Children = append(parent.Children, node)
+	return node
+}
+
+func (node *htmlNode) findAssertion(index int) *htmlAssertion {
+	for _, assertion := range node.Assertions {
+		if assertion.Index == index {
+			return assertion
+		}
+	}
+	return nil
+}
+
+func (node *htmlNode) addHook(hook string) {
+	if strings.HasPrefix(hook, "_after") {
+		node.Teardown = append(node.Teardown, &htmlPhase{Name: hook})
+		return
+	}
+	node.Setup = append(node.Setup, &htmlPhase{Name: hook})
+}
+
+func (node *htmlNode) findHook(hook string) *htmlPhase {
+	hooks := node.Setup
+	if strings.HasPrefix(hook, "_after") {
+		hooks = node.Teardown
+	}
+	for index := len(hooks) - 1; index >= 0; index-- {
+		if hooks[index].Name == hook {
+			return hooks[index]
+		}
+	}
+	return nil
+}
+
+func (sink *HtmlSink) handleScenarioEnter(enter *event.ScenarioEnterEvent) {
+	node := sink.node(enter.Path, "scenario")
+	node.Kind = "scenario"
+	node.Name = enter.Name
+	node.Status = "running"
+	node.Message = ""
+	node.Attempts = enter.Attempt
+	node.Setup = nil
+	node.Run = nil
+	node.Assertions = nil
+	node.Teardown = nil
+}
+
+func (sink *HtmlSink) handleScenarioExit(exit *event.ScenarioExitEvent) {
+	node := sink.node(exit.Path, "scenario")
+	node.DurationMs += exit.DurationMs
+	if exit.Status != "retry" {
+		node.Status = exit.Status
+	}
+}
+
+func (sink *HtmlSink) handleHookEnd(end *event.HookEndEvent) {
+	node := sink.node(end.Path, "context")
+	hook := node.findHook(end.Hook)
+	if hook == nil {
+		return
+	}
+	hook.ExitCode = end.ExitCode
+	hook.DurationMs = end.DurationMs
+	if end.ExitCode == 0 {
+		return
+	}
+	if node.Kind == "scenario" {
+		if node.Message == "" {
+			node.Message = hookFailureMessage(end.Hook, end.ExitCode)
+		}
+		return
+	}
+	sink.failContext(end.Path, hookFailureMessage(end.Hook, end.ExitCode))
+}
+
+func (sink *HtmlSink) failContext(contextPath, message string) {
+	node := sink.node(contextPath, "context")
+	if node.Message == "" {
+		node.Message = message
+	}
+}
+
+func (sink *HtmlSink) handleOutput(output *event.OutputEvent) {
+	node := sink.node(output.Path, "scenario")
+	var stdout, stderr *strings.Builder
+	if index, found := assertionIndex(output.Phase); found {
+		assertion := node.findAssertion(index)
+		if assertion == nil {
+			return
+		}
+		stdout, stderr = &assertion.Output, &assertion.Output
+	} else {
+		phase := node.Run
+		if output.Phase != "_run" {
+			phase = node.findHook(output.Phase)
+		}
+		if phase == nil {
+			return
+		}
+		stdout, stderr = &phase.Stdout, &phase.Stderr
+	}
+	if output.Stream == "stderr" {
+		stderr.WriteString(output.Data)
+	} else {
+		stdout.WriteString(output.Data)
+	}
+}
+
+func (sink *HtmlSink) handleRunEnd(end *event.RunEndEvent) error {
+	sink.report.Status = end.Status
+	sink.report.Passed = end.Passed
+	sink.report.Failed = end.Failed
+	sink.report.Skipped = end.Skipped
+	sink.report.Flaky = end.Flaky
+	sink.report.DurationMs = end.DurationMs
+	for _, root := range sink.report.Roots {
+		root.settleStatus()
+	}
+	var output bytes.Buffer
+	if err := htmlTemplate.Execute(&output, sink.report); err != nil {
+		return err
+	}
+	return sink.fs.WriteFile(sink.filename, output.Bytes())
+}
+
+func (node *htmlNode) settleStatus() string {
+	if node.Kind == "scenario" {
+		if node.Status == "running" {
+			node.Status = "interrupted"
+			node.Message = "scenario did not finish"
+		}
+		return node.Status
+	}
+	node.Status = "skipped"
+	if node.Message != "" {
+		node.Status = "error"
+	}
+	for _, child := range node.Children {
+		switch status := child.settleStatus(); {
+		case status == "skipped":
+		case status == "pass" || status == "flaky":
+			if node.Status == "skipped" {
+				node.Status = "pass"
+			}
+		default:
+			node.Status = "fail"
+		}
+	}
+	return node.Status
+}
+
+func htmlDuration(durationMs int64) string {
+	return fmt.Sprintf("%.2fs", float64(durationMs)/1000)
+}
+
+func htmlOutputLines(output string) []htmlLine {
+	var lines []htmlLine
+	inDiff := false
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		class := ""
+		trimmed := strings.TrimPrefix(line, "  ")
+		switch {
+		case line == "Diff:":
+			inDiff = true
+		case strings.HasPrefix(line, "PASS:"):
+			class = "pass"
+		case strings.HasPrefix(line, "FAIL:"):
+			class = "fail"
+		case inDiff && strings.HasPrefix(trimmed, "@@"):
+			class = "hunk"
+		case inDiff && strings.HasPrefix(trimmed, "+"):
+			class = "add"
+		case inDiff && strings.HasPrefix(trimmed, "-"):
+			class = "del"
+		}
+		lines = append(lines, htmlLine{Class: class, Text: line})
+	}
+	return lines
+}
+
+func htmlOpen(status string) bool {
+	return status != "pass" && status != "skipped"
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": htmlDuration,
+	"lines":    htmlOutputLines,
+	"open":     htmlOpen,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>basanos report {{.RunID}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+h1 { font-size: 1.4em; }
+summary { cursor: pointer; padding: 0.2em 0; }
+details details { margin-left: 1.5em; }
+pre { background: #f6f8fa; padding: 0.6em; overflow-x: auto; margin: 0.3em 0; }
+.counts span { margin-right: 1.5em; font-weight: bold; }
+.status { display: inline-block; min-width: 6em; font-weight: bold; }
+.pass, .flaky { color: #1a7f37; }
+.fail, .error, .interrupted { color: #cf222e; }
+.skipped, .duration, .meta { color: #656d76; }
+.phase { margin-left: 1.5em; }
+.hunk { color: #8250df; }
+.add { color: #1a7f37; background: #dafbe1; }
+.del { color: #cf222e; background: #ffebe9; }
+</style>
+</head>
+<body>
+<h1>basanos run {{.RunID}} <span class="{{.Status}}">{{.Status}}</span></h1>
+<p class="counts"><span class="pass">{{.Passed}} passed</span><span class="fail">{{.Failed}} failed</span><span class="skipped">{{.Skipped}} skipped</span><span class="flaky">{{.Flaky}} flaky</span><span class="duration">{{duration .DurationMs}}</span></p>
+{{range .Roots}}{{template "node" .}}{{end}}
+</body>
+</html>
+{{define "node"}}<details{{if or (eq .Kind "context") (open .Status)}} open{{end}}>
+<summary><span class="status {{.Status}}">{{.Status}}</span> {{.Name}} <span class="meta">{{.Path}}</span>{{if .DurationMs}} <span class="duration">{{duration .DurationMs}}</span>{{end}}{{if gt .Attempts 1}} <span class="meta">{{.Attempts}} attempts</span>{{end}}</summary>
+{{if .Message}}<p class="phase {{.Status}}">{{.Message}}</p>
+{{end}}{{range .Setup}}{{template "phase" .}}{{end}}{{with .Run}}{{template "phase" .}}{{end}}{{range .Assertions}}<div class="phase">
+<div><span class="{{if .ExitCode}}fail{{else}}pass{{end}}">{{.Command}}</span> <span class="meta">exit {{.ExitCode}}</span> <span class="duration">{{duration .DurationMs}}</span></div>
+{{with .Output.String}}<pre>{{range lines .}}<span{{with .Class}} class="{{.}}"{{end}}>{{.Text}}</span>
+{{end}}</pre>{{end}}
+</div>
+{{end}}{{range .Children}}{{template "node" .}}{{end}}{{range .Teardown}}{{template "phase" .}}{{end}}</details>
+{{end}}
+{{define "phase"}}<div class="phase">
+<div>{{.Name}} <span class="meta">exit {{.ExitCode}}</span> <span class="duration">{{duration .DurationMs}}</span></div>
+{{with .Stdout.String}}<pre>{{.}}</pre>{{end}}{{with .Stderr.String}}<pre class="fail">{{.}}</pre>{{end}}
+</div>
+{{end}}`))
@@ -0,0 +1,198 @@
+package sink
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"basanos/internal/event"
+	"basanos/internal/testutil/fs"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func emitHtmlRun(sink Sink, events ...any) {
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewRunStartEvent("run-1", timestamp))
+	for _, incoming := range events {
+		sink.Emit(incoming)
+	}
+}
+
+func readHtmlReport(t *testing.T, memFS *fs.MemoryFS) string {
+	content, err := memFS.ReadFile("report.html")
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestHtmlSink_WritesReportOnlyAtRunEnd(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	sink := NewHtmlSink(memFS, "report.html")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+
+	emitHtmlRun(sink, event.NewContextEnterEvent("run-1", "api", "API Tests", timestamp))
+	_, err := memFS.ReadFile("report.html")
+	require.Error(t, err)
+
+	sink.Emit(event.NewRunEndEvent("run-1", "pass", 3, 1, 2, 1, timestamp, 2500*time.Millisecond))
+
+	report := readHtmlReport(t, memFS)
+	assert.Contains(t, report, "<!DOCTYPE html>")
+	assert.Contains(t, report, "<style>")
+	assert.NotContains(t, report, "<link")
+	assert.NotContains(t, report, "<script src")
+	assert.Contains(t, report, `<span class="pass">3 passed</span>`)
+	assert.Contains(t, report, `<span class="fail">1 failed</span>`)
+	assert.Contains(t, report, `<span class="skipped">2 skipped</span>`)
+	assert.Contains(t, report, `<span class="flaky">1 flaky</span>`)
+	assert.Contains(t, report, `<span class="duration">2.50s</span>`)
+}
+
+func TestHtmlSink_NestsContextsGroupsAndScenarios(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	sink := NewHtmlSink(memFS, "report.html")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+
+	emitHtmlRun(sink,
+		event.NewContextEnterEvent("run-1", "api", "API Tests", timestamp),
+		event.NewScenarioEnterEvent("run-1", "api/auth/login", "Login works", 1, timestamp),
+		event.NewScenarioExitEvent("run-1", "api/auth/login", "pass", 1, timestamp, 412*time.Millisecond),
+		event.NewContextEnterEvent("run-1", "api/admin", "Admin", timestamp),
+		event.NewScenarioSkippedEvent("run-1", "api/admin/users", "Lists users", "filter", timestamp),
+		event.NewContextExitEvent("run-1", "api/admin", timestamp, 0),
+		event.NewContextExitEvent("run-1", "api", timestamp, time.Second),
+		event.NewRunEndEvent("run-1", "pass", 1, 0, 1, 0, timestamp, time.Second),
+	)
+
+	report := readHtmlReport(t, memFS)
+	apiIndex := indexOf(t, report, "API Tests <span class=\"meta\">api</span>")
+	groupIndex := indexOf(t, report, "auth <span class=\"meta\">api/auth</span>")
+	loginIndex := indexOf(t, report, "Login works <span class=\"meta\">api/auth/login</span> <span class=\"duration\">0.41s</span>")
+	adminIndex := indexOf(t, report, "Admin <span class=\"meta\">api/admin</span>")
+	assert.Less(t, apiIndex, groupIndex)
+	assert.Less(t, groupIndex, loginIndex)
+	assert.Less(t, loginIndex, adminIndex)
+	assert.Contains(t, report, `<span class="status skipped">skipped</span> Admin`)
+	assert.Contains(t, report, `<span class="status skipped">skipped</span> Lists users`)
+	assert.Contains(t, report, `<span class="status pass">pass</span> API Tests`)
+}
+
+func TestHtmlSink_DotRootIsReported(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	sink := NewHtmlSink(memFS, "report.html")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+
+	emitHtmlRun(sink,
+		event.NewContextEnterEvent("run-1", ".", "Root Tests", timestamp),
+		event.NewScenarioEnterEvent("run-1", "./login", "Login works", 1, timestamp),
+		event.NewScenarioExitEvent("run-1", "./login", "pass", 1, timestamp, 0),
+		event.NewContextExitEvent("run-1", ".", timestamp, 0),
+		event.NewRunEndEvent("run-1", "pass", 1, 0, 0, 0, timestamp, time.Second),
+	)
+
+	report := readHtmlReport(t, memFS)
+	rootIndex := indexOf(t, report, "Root Tests <span class=\"meta\">.</span>")
+	loginIndex := indexOf(t, report, "Login works <span class=\"meta\">./login</span>")
+	assert.Less(t, rootIndex, loginIndex)
+}
+
+func indexOf(t *testing.T, report, fragment string) int {
+	index := strings.Index(report, fragment)
+	require.NotEqual(t, -1, index, "report does not contain %q", fragment)
+	return index
+}
+
+func TestHtmlSink_ShowsHookRunAndAssertionOutput(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	sink := NewHtmlSink(memFS, "report.html")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+
+	emitHtmlRun(sink,
+		event.NewContextEnterEvent("run-1", "api", "API Tests", timestamp),
+		event.NewScenarioEnterEvent("run-1", "api/login", "Login", 1, timestamp),
+		event.NewHookStartEvent("run-1", "api/login", "_before_each", "", timestamp),
+		event.NewOutputEvent("run-1", "api/login", "_before_each", 1, "stdout", "seeding <users>\n"),
+		event.NewHookEndEvent("run-1", "api/login", "_before_each", "", 0, 0),
+		event.NewScenarioRunStartEvent("run-1", "api/login", timestamp),
+		event.NewOutputEvent("run-1", "api/login", "_run", 2, "stdout", "logging in\n"),
+		event.NewOutputEvent("run-1", "api/login", "_run", 3, "stderr", "slow response\n"),
+		event.NewScenarioRunEndEvent("run-1", "api/login", 0, 0),
+		event.NewAssertionStartEvent("run-1", "api/login", 0, "assert_equals welcome ${RUN_OUTPUT}/stdout", timestamp),
+		event.NewOutputEvent("run-1", "api/login", "_assertions/0", 4, "stdout", "FAIL: values differ\nDiff:\n  @@ -1 +1 @@\n  -welcome\n  +logging in\n"),
+		event.NewAssertionEndEvent("run-1", "api/login", 0, 1, 1, "", 0),
+		event.NewHookStartEvent("run-1", "api/login", "_after_each", "", timestamp),
+		event.NewHookEndEvent("run-1", "api/login", "_after_each", "", 0, 0),
+		event.NewScenarioExitEvent("run-1", "api/login", "fail", 1, timestamp, 0),
+		event.NewContextExitEvent("run-1", "api", timestamp, 0),
+		event.NewRunEndEvent("run-1", "fail", 0, 1, 0, 0, timestamp, 0),
+	)
+
+	report := readHtmlReport(t, memFS)
+	assert.Contains(t, report, "<pre>seeding &lt;users&gt;\n</pre>")
+	assert.Contains(t, report, "<pre>logging in\n</pre>")
+	assert.Contains(t, report, `<pre class="fail">slow response`+"\n</pre>")
+	assert.Contains(t, report, `<span class="fail">assert_equals welcome ${RUN_OUTPUT}/stdout</span> <span class="meta">exit 1</span>`)
+	assert.Contains(t, report, `<span class="hunk">  @@ -1 &#43;1 @@</span>`)
+	assert.Contains(t, report, `<span class="del">  -welcome</span>`)
+	assert.Contains(t, report, `<span class="add">  &#43;logging in</span>`)
+	assert.Contains(t, report, `<details open>`+"\n"+`<summary><span class="status fail">fail</span> Login`)
+	assert.Less(t, indexOf(t, report, "_before_each"), indexOf(t, report, "<div>run "))
+	assert.Less(t, indexOf(t, report, "<div>run "), indexOf(t, report, "_after_each"))
+	assert.Contains(t, report, `<span class="status fail">fail</span> API Tests`)
+}
+
+func TestHtmlSink_KeepsOnlyTheFinalAttempt(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	sink := NewHtmlSink(memFS, "report.html")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+
+	emitHtmlRun(sink,
+		event.NewContextEnterEvent("run-1", "api", "API Tests", timestamp),
+		event.NewScenarioEnterEvent("run-1", "api/login", "Login", 1, timestamp),
+		event.NewScenarioRunStartEvent("run-1", "api/login", timestamp),
+		event.NewOutputEvent("run-1", "api/login", "_run", 1, "stdout", "first attempt\n"),
+		event.NewScenarioExitEvent("run-1", "api/login", "retry", 1, timestamp, time.Second),
+		event.NewScenarioEnterEvent("run-1", "api/login", "Login", 2, timestamp),
+		event.NewScenarioRunStartEvent("run-1", "api/login", timestamp),
+		event.NewOutputEvent("run-1", "api/login", "_run", 2, "stdout", "second attempt\n"),
+		event.NewScenarioExitEvent("run-1", "api/login", "flaky", 2, timestamp, time.Second),
+		event.NewContextExitEvent("run-1", "api", timestamp, 0),
+		event.NewRunEndEvent("run-1", "pass", 1, 0, 0, 1, timestamp, 0),
+	)
+
+	report := readHtmlReport(t, memFS)
+	assert.NotContains(t, report, "first attempt")
+	assert.Contains(t, report, "second attempt")
+	assert.Contains(t, report, `<span class="duration">2.00s</span> <span class="meta">2 attempts</span>`)
+	assert.Contains(t, report, `<span class="status flaky">flaky</span> Login`)
+}
+
+func TestHtmlSink_ReportsContextHookFailuresAndTimeouts(t *testing.T) {
+	memFS := fs.NewMemoryFS()
+	sink := NewHtmlSink(memFS, "report.html")
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+
+	emitHtmlRun(sink,
+		event.NewContextEnterEvent("run-1", "api", "API Tests", timestamp),
+		event.NewHookStartEvent("run-1", "api", "_before", "", timestamp),
+		event.NewOutputEvent("run-1", "api", "_before", 1, "stderr", "database unreachable\n"),
+		event.NewHookEndEvent("run-1", "api", "_before", "", 3, 0),
+		event.NewScenarioSkippedEvent("run-1", "api/login", "Login", "hook_failure", timestamp),
+		event.NewContextEnterEvent("run-1", "slow", "Slow", timestamp),
+		event.NewScenarioEnterEvent("run-1", "slow/wait", "Wait", 1, timestamp),
+		event.NewScenarioRunStartEvent("run-1", "slow/wait", timestamp),
+		event.NewTimeoutEvent("run-1", "slow/wait", "run", "1s"),
+		event.NewScenarioExitEvent("run-1", "slow/wait", "fail", 1, timestamp, 0),
+		event.NewScenarioEnterEvent("run-1", "slow/never", "Never finishes", 1, timestamp),
+		event.NewRunEndEvent("run-1", "interrupted", 0, 1, 1, 0, timestamp, 0),
+	)
+
+	report := readHtmlReport(t, memFS)
+	assert.Contains(t, report, `<span class="status error">error</span> API Tests`)
+	assert.Contains(t, report, `<p class="phase error">_before hook exited with code 3</p>`)
+	assert.Contains(t, report, `<pre class="fail">database unreachable`)
+	assert.Contains(t, report, `<p class="phase fail">run timed out after 1s</p>`)
+	assert.Contains(t, report, `<span class="status interrupted">interrupted</span> Never finishes`)
+	assert.Contains(t, report, `<p class="phase interrupted">scenario did not finish</p>`)
+}
@@ -4,17 +4,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+
+	"basanos/internal/event"
 )
 
 type JsonStreamSink struct {
-	writer io.Writer
+	writer           io.Writer
+	legacyEventNames bool
 }
 
 func NewJsonStreamSink(writer io.Writer) Sink {
 	return &JsonStreamSink{writer: writer}
 }
 
+func NewLegacyJsonStreamSink(writer io.Writer) Sink {
+	return &JsonStreamSink{writer: writer, legacyEventNames: true}
+}
+
 func (sink *JsonStreamSink) Emit(incoming any) error {
+	if sink.legacyEventNames {
+		incoming = legacyEvent(incoming)
+	}
 	data, err := json.Marshal(incoming)
 	if err != nil {
 		return err
@@ -22,3 +32,21 @@ func (sink *JsonStreamSink) Emit(incoming any) error {
 	_, err = fmt.Fprintf(sink.writer, "%s\n", data)
 	return err
 }
+
+func legacyEvent(incoming any) any {
+	switch typed := incoming.(type) {
+	case *event.RunStartEvent:
+		legacy := *typed
+		legacy.SchemaVersion = 1
+		return &legacy
+	case *event.ScenarioRunStartEvent:
+		legacy := *typed
+		legacy.Event = "run_start"
+		return &legacy
+	case *event.ScenarioRunEndEvent:
+		legacy := *typed
+		legacy.Event = "run_end"
+		return &legacy
+	}
+	return incoming
+}
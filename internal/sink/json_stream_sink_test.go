@@ -25,3 +25,33 @@ func TestJsonStreamSink_EmitsNDJSON(t *testing.T) {
 	assert.Equal(t, "run_start", result["event"])
 	assert.Equal(t, "2026-01-15_143022", result["run_id"])
 }
+
+func TestJsonStreamSink_UsesScenarioRunEventNames(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJsonStreamSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewScenarioRunStartEvent("run-1", "api/login", timestamp))
+	sink.Emit(event.NewScenarioRunEndEvent("run-1", "api/login", 0, 1250*time.Millisecond))
+
+	assert.Equal(t, `{"event":"scenario_run_start","run_id":"run-1","path":"api/login","timestamp":"2026-01-15T14:30:22Z"}
+{"event":"scenario_run_end","run_id":"run-1","path":"api/login","exit_code":0,"duration_ms":1250}
+`, buffer.String())
+}
+
+func TestLegacyJsonStreamSink_EmitsVersionOneEventNames(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewLegacyJsonStreamSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runStart := event.NewRunStartEvent("run-1", timestamp)
+	sink.Emit(runStart)
+	sink.Emit(event.NewScenarioRunStartEvent("run-1", "api/login", timestamp))
+	sink.Emit(event.NewScenarioRunEndEvent("run-1", "api/login", 0, 1250*time.Millisecond))
+
+	assert.Equal(t, `{"event":"run_start","run_id":"run-1","schema_version":1,"timestamp":"2026-01-15T14:30:22Z"}
+{"event":"run_start","run_id":"run-1","path":"api/login","timestamp":"2026-01-15T14:30:22Z"}
+{"event":"run_end","run_id":"run-1","path":"api/login","exit_code":0,"duration_ms":1250}
+`, buffer.String())
+	assert.Equal(t, event.SchemaVersion, runStart.SchemaVersion)
+}
@@ -4,8 +4,9 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"maps"
 	"path/filepath"
-	"time"
+	"slices"
 
 	"basanos/internal/event"
 )
@@ -14,6 +15,8 @@ type junitTestSuites struct {
 	XMLName  xml.Name         `xml:"testsuites"`
 	Tests    int              `xml:"tests,attr"`
 	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
 	Suites   []junitTestSuite `xml:"testsuite"`
 }
 
@@ -21,41 +24,66 @@ type junitTestSuite struct {
 	Name     string          `xml:"name,attr"`
 	Tests    int             `xml:"tests,attr"`
 	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
 	Time     string          `xml:"time,attr"`
 	Cases    []junitTestCase `xml:"testcase"`
 }
 
 type junitTestCase struct {
-	Name      string        `xml:"name,attr"`
-	Classname string        `xml:"classname,attr"`
-	Time      string        `xml:"time,attr"`
-	Failure   *junitFailure `xml:"failure,omitempty"`
+	Name          string              `xml:"name,attr"`
+	Classname     string              `xml:"classname,attr"`
+	Time          string              `xml:"time,attr"`
+	Failure       *junitFailure       `xml:"failure,omitempty"`
+	Error         *junitError         `xml:"error,omitempty"`
+	Skipped       *junitSkipped       `xml:"skipped,omitempty"`
+	FlakyFailures []junitFlakyFailure `xml:"flakyFailure"`
+	SystemOut     string              `xml:"system-out,omitempty"`
+	SystemErr     string              `xml:"system-err,omitempty"`
 }
 
 type junitFailure struct {
 	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitError struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Body    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFlakyFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
 }
 
 type pendingCase struct {
-	name      string
-	classname string
-	startTime time.Time
+	attemptDetails
+	name          string
+	classname     string
+	durationMs    int64
+	flakyFailures []junitFlakyFailure
 }
 
 type JunitSink struct {
-	writer           io.Writer
-	suites           map[string]*junitTestSuite
-	suiteOrder       []string
-	pendingCases     map[string]*pendingCase
-	contextStartTime map[string]time.Time
+	writer          io.Writer
+	suites          map[string]*junitTestSuite
+	suiteOrder      []string
+	pendingCases    map[string]*pendingCase
+	contextTimeouts map[string]string
 }
 
 func NewJunitSink(writer io.Writer) Sink {
 	return &JunitSink{
-		writer:           writer,
-		suites:           make(map[string]*junitTestSuite),
-		pendingCases:     make(map[string]*pendingCase),
-		contextStartTime: make(map[string]time.Time),
+		writer:          writer,
+		suites:          make(map[string]*junitTestSuite),
+		pendingCases:    make(map[string]*pendingCase),
+		contextTimeouts: make(map[string]string),
 	}
 }
 
@@ -69,6 +97,18 @@ func (sink *JunitSink) Emit(incoming any) error {
 		sink.handleScenarioEnter(typed)
 	case *event.ScenarioExitEvent:
 		sink.handleScenarioExit(typed)
+	case *event.ScenarioSkippedEvent:
+		sink.handleScenarioSkipped(typed)
+	case *event.HookEndEvent:
+		sink.handleHookEnd(typed)
+	case *event.TimeoutEvent:
+		sink.handleTimeout(typed)
+	case *event.AssertionStartEvent:
+		sink.handleAssertionStart(typed)
+	case *event.AssertionEndEvent:
+		sink.handleAssertionEnd(typed)
+	case *event.OutputEvent:
+		sink.handleOutput(typed)
 	case *event.RunEndEvent:
 		return sink.handleRunEnd(typed)
 	}
@@ -80,39 +120,62 @@ func (sink *JunitSink) handleContextEnter(enter *event.ContextEnterEvent) {
 		Name: enter.Path,
 	}
 	sink.suiteOrder = append(sink.suiteOrder, enter.Path)
-	sink.contextStartTime[enter.Path] = enter.Timestamp
 }
 
 func (sink *JunitSink) handleContextExit(exit *event.ContextExitEvent) {
-	suite := sink.suites[exit.Path]
-	startTime := sink.contextStartTime[exit.Path]
-	duration := exit.Timestamp.Sub(startTime).Seconds()
-	suite.Time = fmt.Sprintf("%.3f", duration)
+	if suite, exists := sink.suites[exit.Path]; exists {
+		suite.Time = junitTime(exit.DurationMs)
+	}
+}
+
+func junitTime(durationMs int64) string {
+	return fmt.Sprintf("%.3f", float64(durationMs)/1000)
 }
 
 func (sink *JunitSink) handleScenarioEnter(enter *event.ScenarioEnterEvent) {
+	if pending, exists := sink.pendingCases[enter.Path]; exists && enter.Attempt > 1 {
+		pending.resetAttempt()
+		return
+	}
 	contextPath := filepath.Dir(enter.Path)
 	sink.pendingCases[enter.Path] = &pendingCase{
 		name:      enter.Name,
 		classname: contextPath,
-		startTime: enter.Timestamp,
 	}
 }
 
 func (sink *JunitSink) handleScenarioExit(exit *event.ScenarioExitEvent) {
-	pending := sink.pendingCases[exit.Path]
-	suite := sink.findSuiteForPath(exit.Path)
+	pending, exists := sink.pendingCases[exit.Path]
+	if !exists {
+		pending = &pendingCase{name: filepath.Base(exit.Path), classname: filepath.Dir(exit.Path)}
+	}
+	pending.durationMs += exit.DurationMs
+	if exit.Status == "retry" {
+		pending.flakyFailures = append(pending.flakyFailures, attemptFailure(pending.hookFailure, exit.Attempt))
+		return
+	}
+	suite := sink.suiteForScenario(exit.Path)
 
-	duration := exit.Timestamp.Sub(pending.startTime).Seconds()
 	testCase := junitTestCase{
 		Name:      pending.name,
 		Classname: pending.classname,
-		Time:      fmt.Sprintf("%.3f", duration),
+		Time:      junitTime(pending.durationMs),
+		SystemOut: pending.stdout.String(),
+		SystemErr: pending.stderr.String(),
 	}
 
-	if exit.Status == "fail" {
-		testCase.Failure = &junitFailure{Message: "test failed"}
+	switch {
+	case exit.Status != "pass" && exit.Status != "flaky" && pending.timeout != "":
+		testCase.Error = &junitError{Message: pending.timeout, Type: "timeout", Body: pending.assertionReport()}
+		suite.Errors++
+	case exit.Status == "fail":
+		testCase.Failure = &junitFailure{Message: pending.failureMessage(), Body: pending.assertionReport()}
 		suite.Failures++
+	case exit.Status == "error":
+		testCase.Error = &junitError{Message: hookErrorMessage(pending.hookFailure), Type: "hook"}
+		suite.Errors++
+	case exit.Status == "flaky":
+		testCase.FlakyFailures = pending.flakyFailures
 	}
 	suite.Cases = append(suite.Cases, testCase)
 	suite.Tests++
@@ -120,6 +183,87 @@ func (sink *JunitSink) handleScenarioExit(exit *event.ScenarioExitEvent) {
 	delete(sink.pendingCases, exit.Path)
 }
 
+func (sink *JunitSink) handleScenarioSkipped(skipped *event.ScenarioSkippedEvent) {
+	suite := sink.suiteForScenario(skipped.Path)
+	suite.Cases = append(suite.Cases, junitTestCase{
+		Name:      skipped.Name,
+		Classname: filepath.Dir(skipped.Path),
+		Time:      "0.000",
+		Skipped:   &junitSkipped{Message: skipped.Reason},
+	})
+	suite.Tests++
+	suite.Skipped++
+}
+
+func (sink *JunitSink) handleTimeout(timeout *event.TimeoutEvent) {
+	if pending, exists := sink.pendingCases[timeout.Path]; exists {
+		pending.recordTimeout(timeoutMessage(timeout))
+		return
+	}
+	sink.contextTimeouts[timeout.Path] = timeoutMessage(timeout)
+}
+
+func (sink *JunitSink) handleAssertionStart(start *event.AssertionStartEvent) {
+	if pending, exists := sink.pendingCases[start.Path]; exists {
+		pending.recordAssertionStart(start)
+	}
+}
+
+func (sink *JunitSink) handleAssertionEnd(end *event.AssertionEndEvent) {
+	if pending, exists := sink.pendingCases[end.Path]; exists {
+		pending.recordAssertionEnd(end)
+	}
+}
+
+func (sink *JunitSink) handleOutput(output *event.OutputEvent) {
+	if pending, exists := sink.pendingCases[output.Path]; exists {
+		pending.recordOutput(output)
+	}
+}
+
+func (sink *JunitSink) handleHookEnd(end *event.HookEndEvent) {
+	timeout, timedOut := sink.contextTimeouts[end.Path]
+	delete(sink.contextTimeouts, end.Path)
+	if end.ExitCode == 0 {
+		return
+	}
+	message := hookFailureMessage(end.Hook, end.ExitCode)
+	if pending, exists := sink.pendingCases[end.Path]; exists {
+		pending.recordHookFailure(message)
+		return
+	}
+	suite, exists := sink.suites[end.Path]
+	if !exists {
+		return
+	}
+	hookError := &junitError{Message: message, Type: "hook"}
+	if timedOut {
+		hookError = &junitError{Message: timeout, Type: "timeout"}
+	}
+	suite.Cases = append(suite.Cases, junitTestCase{
+		Name:      end.Hook,
+		Classname: end.Path,
+		Time:      "0.000",
+		Error:     hookError,
+	})
+	suite.Tests++
+	suite.Errors++
+}
+
+func attemptFailure(hookFailure string, attempt int) junitFlakyFailure {
+	if hookFailure != "" {
+		return junitFlakyFailure{Message: fmt.Sprintf("attempt %d: %s", attempt, hookFailure), Type: "hook"}
+	}
+	return junitFlakyFailure{Message: fmt.Sprintf("attempt %d failed", attempt), Type: "failure"}
+}
+
+func hookErrorMessage(hookFailure string) string {
+	if hookFailure == "" {
+		return "hook failed"
+	}
+	return hookFailure
+}
+
 func (sink *JunitSink) findSuiteForPath(scenarioPath string) *junitTestSuite {
 	path := scenarioPath
 	for path != "." && path != "" {
@@ -131,14 +275,42 @@ func (sink *JunitSink) findSuiteForPath(scenarioPath string) *junitTestSuite {
 	return nil
 }
 
-func (sink *JunitSink) handleRunEnd(end *event.RunEndEvent) error {
-	testsuites := junitTestSuites{
-		Tests:    end.Passed + end.Failed,
-		Failures: end.Failed,
+func (sink *JunitSink) suiteForScenario(scenarioPath string) *junitTestSuite {
+	if suite := sink.findSuiteForPath(scenarioPath); suite != nil {
+		return suite
 	}
+	contextPath := filepath.Dir(scenarioPath)
+	sink.handleContextEnter(&event.ContextEnterEvent{Path: contextPath})
+	return sink.suites[contextPath]
+}
+
+func (sink *JunitSink) closePendingCases() {
+	for _, path := range slices.Sorted(maps.Keys(sink.pendingCases)) {
+		pending := sink.pendingCases[path]
+		suite := sink.suiteForScenario(path)
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      pending.name,
+			Classname: pending.classname,
+			Time:      "0.000",
+			Error:     &junitError{Message: "scenario did not finish", Type: "interrupted"},
+		})
+		suite.Tests++
+		suite.Errors++
+		delete(sink.pendingCases, path)
+	}
+}
+
+func (sink *JunitSink) handleRunEnd(end *event.RunEndEvent) error {
+	sink.closePendingCases()
+	testsuites := junitTestSuites{}
 
 	for _, path := range sink.suiteOrder {
-		testsuites.Suites = append(testsuites.Suites, *sink.suites[path])
+		suite := sink.suites[path]
+		testsuites.Tests += suite.Tests
+		testsuites.Failures += suite.Failures
+		testsuites.Errors += suite.Errors
+		testsuites.Skipped += suite.Skipped
+		testsuites.Suites = append(testsuites.Suites, *suite)
 	}
 
 	output, err := xml.MarshalIndent(testsuites, "", "  ")
@@ -21,10 +21,10 @@ func TestJunitSink_WritesValidXML(t *testing.T) {
 
 	sink.Emit(event.NewRunStartEvent(runID, timestamp))
 	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
-	sink.Emit(event.NewScenarioEnterEvent(runID, "api/health_check", "Health Check", timestamp))
-	sink.Emit(event.NewScenarioExitEvent(runID, "api/health_check", "pass", timestamp.Add(100*time.Millisecond)))
-	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp.Add(100*time.Millisecond)))
-	sink.Emit(event.NewRunEndEvent(runID, "pass", 1, 0, timestamp.Add(100*time.Millisecond)))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/health_check", "Health Check", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/health_check", "pass", 1, timestamp.Add(100*time.Millisecond), 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp.Add(100*time.Millisecond), 0))
+	sink.Emit(event.NewRunEndEvent(runID, "pass", 1, 0, 0, 0, timestamp.Add(100*time.Millisecond), 0))
 
 	output := buffer.String()
 
@@ -70,10 +70,10 @@ func TestJunitSink_IncludesFailureElement(t *testing.T) {
 
 	sink.Emit(event.NewRunStartEvent(runID, timestamp))
 	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
-	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", timestamp))
-	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "fail", timestamp.Add(200*time.Millisecond)))
-	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp.Add(200*time.Millisecond)))
-	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, timestamp.Add(200*time.Millisecond)))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "fail", 1, timestamp.Add(200*time.Millisecond), 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp.Add(200*time.Millisecond), 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 0, 0, timestamp.Add(200*time.Millisecond), 0))
 
 	output := buffer.String()
 
@@ -109,10 +109,10 @@ func TestJunitSink_NestedScenarioGroups_FindsCorrectSuite(t *testing.T) {
 
 	sink.Emit(event.NewRunStartEvent(runID, timestamp))
 	sink.Emit(event.NewContextEnterEvent(runID, "spec/assertions", "Assertions", timestamp))
-	sink.Emit(event.NewScenarioEnterEvent(runID, "spec/assertions/contains/substring_found", "Substring found", timestamp))
-	sink.Emit(event.NewScenarioExitEvent(runID, "spec/assertions/contains/substring_found", "pass", timestamp.Add(100*time.Millisecond)))
-	sink.Emit(event.NewContextExitEvent(runID, "spec/assertions", timestamp.Add(100*time.Millisecond)))
-	sink.Emit(event.NewRunEndEvent(runID, "pass", 1, 0, timestamp.Add(100*time.Millisecond)))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "spec/assertions/contains/substring_found", "Substring found", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent(runID, "spec/assertions/contains/substring_found", "pass", 1, timestamp.Add(100*time.Millisecond), 0))
+	sink.Emit(event.NewContextExitEvent(runID, "spec/assertions", timestamp.Add(100*time.Millisecond), 0))
+	sink.Emit(event.NewRunEndEvent(runID, "pass", 1, 0, 0, 0, timestamp.Add(100*time.Millisecond), 0))
 
 	output := buffer.String()
 
@@ -147,12 +147,12 @@ func TestJunitSink_TestSuiteIncludesTime(t *testing.T) {
 
 	sink.Emit(event.NewRunStartEvent(runID, timestamp))
 	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
-	sink.Emit(event.NewScenarioEnterEvent(runID, "api/health", "Health Check", timestamp.Add(10*time.Millisecond)))
-	sink.Emit(event.NewScenarioExitEvent(runID, "api/health", "pass", timestamp.Add(60*time.Millisecond)))
-	sink.Emit(event.NewScenarioEnterEvent(runID, "api/status", "Status Check", timestamp.Add(70*time.Millisecond)))
-	sink.Emit(event.NewScenarioExitEvent(runID, "api/status", "pass", timestamp.Add(120*time.Millisecond)))
-	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp.Add(150*time.Millisecond)))
-	sink.Emit(event.NewRunEndEvent(runID, "pass", 2, 0, timestamp.Add(150*time.Millisecond)))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/health", "Health Check", 1, timestamp.Add(10*time.Millisecond)))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/health", "pass", 1, timestamp.Add(60*time.Millisecond), 50*time.Millisecond))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/status", "Status Check", 1, timestamp.Add(70*time.Millisecond)))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/status", "pass", 1, timestamp.Add(120*time.Millisecond), 50*time.Millisecond))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp.Add(150*time.Millisecond), 150*time.Millisecond))
+	sink.Emit(event.NewRunEndEvent(runID, "pass", 2, 0, 0, 0, timestamp.Add(150*time.Millisecond), 0))
 
 	output := buffer.String()
 
@@ -169,5 +169,456 @@ func TestJunitSink_TestSuiteIncludesTime(t *testing.T) {
 
 	require.Len(t, testsuites.Suites, 1)
 	suite := testsuites.Suites[0]
-	assert.Equal(t, "0.150", suite.Time, "Suite time should be duration from context enter to exit")
+	assert.Equal(t, "0.150", suite.Time, "Suite time should be the context duration")
+}
+
+func TestJunitSink_TestCaseTimeUsesMeasuredDuration(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/health", "Health Check", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/health", "pass", 1, timestamp.Add(-time.Second), 1234*time.Millisecond))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp.Add(-time.Second), 1240*time.Millisecond))
+	sink.Emit(event.NewRunEndEvent(runID, "pass", 1, 0, 0, 0, timestamp, 1240*time.Millisecond))
+
+	var testsuites struct {
+		Suites []struct {
+			Time  string `xml:"time,attr"`
+			Cases []struct {
+				Time string `xml:"time,attr"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+
+	err := xml.Unmarshal(buffer.Bytes(), &testsuites)
+	require.NoError(t, err)
+
+	require.Len(t, testsuites.Suites, 1)
+	assert.Equal(t, "1.240", testsuites.Suites[0].Time)
+	require.Len(t, testsuites.Suites[0].Cases, 1)
+	assert.Equal(t, "1.234", testsuites.Suites[0].Cases[0].Time)
+}
+
+func TestJunitSink_ErroredScenarioUsesErrorElement(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewHookEndEvent(runID, "api/login", "_before_each", "", 3, 0))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "error", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 0, 0, timestamp, 0))
+
+	var testsuites struct {
+		Errors   int `xml:"errors,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Errors int `xml:"errors,attr"`
+			Cases  []struct {
+				Failure *struct{} `xml:"failure"`
+				Error   *struct {
+					Message string `xml:"message,attr"`
+					Type    string `xml:"type,attr"`
+				} `xml:"error"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+
+	err := xml.Unmarshal(buffer.Bytes(), &testsuites)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, testsuites.Errors)
+	assert.Equal(t, 0, testsuites.Failures)
+	require.Len(t, testsuites.Suites[0].Cases, 1)
+	testcase := testsuites.Suites[0].Cases[0]
+	assert.Nil(t, testcase.Failure)
+	require.NotNil(t, testcase.Error)
+	assert.Equal(t, "_before_each hook exited with code 3", testcase.Error.Message)
+	assert.Equal(t, "hook", testcase.Error.Type)
+}
+
+func TestJunitSink_FailedContextHookBecomesErrorCase(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/health", "Health", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/health", "pass", 1, timestamp, 0))
+	sink.Emit(event.NewHookEndEvent(runID, "api", "_after", "", 1, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 1, 0, 0, 0, timestamp, 0))
+
+	var testsuites struct {
+		Tests  int `xml:"tests,attr"`
+		Errors int `xml:"errors,attr"`
+		Suites []struct {
+			Cases []struct {
+				Name      string    `xml:"name,attr"`
+				Classname string    `xml:"classname,attr"`
+				Error     *struct{} `xml:"error"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+
+	err := xml.Unmarshal(buffer.Bytes(), &testsuites)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, testsuites.Tests)
+	assert.Equal(t, 1, testsuites.Errors)
+	require.Len(t, testsuites.Suites[0].Cases, 2)
+	hookCase := testsuites.Suites[0].Cases[1]
+	assert.Equal(t, "_after", hookCase.Name)
+	assert.Equal(t, "api", hookCase.Classname)
+	assert.NotNil(t, hookCase.Error)
+}
+
+func TestJunitSink_IncludesSkippedElement(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioSkippedEvent(runID, "api/logout", "Logout", "skip_children", timestamp))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 1, 0, timestamp, 0))
+
+	var testsuites struct {
+		Tests   int `xml:"tests,attr"`
+		Skipped int `xml:"skipped,attr"`
+		Suites  []struct {
+			Skipped int `xml:"skipped,attr"`
+			Cases   []struct {
+				Name    string `xml:"name,attr"`
+				Skipped *struct {
+					Message string `xml:"message,attr"`
+				} `xml:"skipped"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+
+	err := xml.Unmarshal(buffer.Bytes(), &testsuites)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, testsuites.Tests)
+	assert.Equal(t, 1, testsuites.Skipped)
+	assert.Equal(t, 1, testsuites.Suites[0].Skipped)
+	require.Len(t, testsuites.Suites[0].Cases, 2)
+	skippedCase := testsuites.Suites[0].Cases[1]
+	assert.Equal(t, "Logout", skippedCase.Name)
+	require.NotNil(t, skippedCase.Skipped)
+	assert.Equal(t, "skip_children", skippedCase.Skipped.Message)
+}
+
+func TestJunitSink_FlakyScenarioRecordsFlakyFailures(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	startTime := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	endTime := startTime.Add(3 * time.Second)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "agents", "Agents", startTime))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "agents/plan", "Plans", 1, startTime))
+	sink.Emit(event.NewScenarioExitEvent(runID, "agents/plan", "retry", 1, startTime, 500*time.Millisecond))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "agents/plan", "Plans", 2, startTime))
+	sink.Emit(event.NewHookEndEvent(runID, "agents/plan", "_before_each", "", 1, 0))
+	sink.Emit(event.NewScenarioExitEvent(runID, "agents/plan", "retry", 2, startTime, 1000*time.Millisecond))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "agents/plan", "Plans", 3, startTime))
+	sink.Emit(event.NewScenarioExitEvent(runID, "agents/plan", "flaky", 3, endTime, 1500*time.Millisecond))
+	sink.Emit(event.NewContextExitEvent(runID, "agents", endTime, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "pass", 1, 0, 0, 1, endTime, 0))
+
+	var testsuites struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Suites   []struct {
+			Cases []struct {
+				Time          string    `xml:"time,attr"`
+				Failure       *struct{} `xml:"failure"`
+				FlakyFailures []struct {
+					Message string `xml:"message,attr"`
+					Type    string `xml:"type,attr"`
+				} `xml:"flakyFailure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+
+	err := xml.Unmarshal(buffer.Bytes(), &testsuites)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, testsuites.Tests)
+	assert.Equal(t, 0, testsuites.Failures)
+	require.Len(t, testsuites.Suites[0].Cases, 1)
+	testcase := testsuites.Suites[0].Cases[0]
+	assert.Nil(t, testcase.Failure)
+	assert.Equal(t, "3.000", testcase.Time)
+	require.Len(t, testcase.FlakyFailures, 2)
+	assert.Equal(t, "attempt 1 failed", testcase.FlakyFailures[0].Message)
+	assert.Equal(t, "failure", testcase.FlakyFailures[0].Type)
+	assert.Equal(t, "attempt 2: _before_each hook exited with code 1", testcase.FlakyFailures[1].Message)
+	assert.Equal(t, "hook", testcase.FlakyFailures[1].Type)
+}
+
+func TestJunitSink_UnfinishedScenarioBecomesInterruptedError(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewRunInterruptedEvent(runID, "panic", "boom", timestamp))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "interrupted", 0, 0, 0, 0, timestamp, 0))
+
+	var testsuites struct {
+		Tests  int `xml:"tests,attr"`
+		Errors int `xml:"errors,attr"`
+		Suites []struct {
+			Cases []struct {
+				Name  string `xml:"name,attr"`
+				Error *struct {
+					Message string `xml:"message,attr"`
+					Type    string `xml:"type,attr"`
+				} `xml:"error"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+
+	err := xml.Unmarshal(buffer.Bytes(), &testsuites)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, testsuites.Tests)
+	assert.Equal(t, 1, testsuites.Errors)
+	testcase := testsuites.Suites[0].Cases[0]
+	assert.Equal(t, "Login", testcase.Name)
+	require.NotNil(t, testcase.Error)
+	assert.Equal(t, "interrupted", testcase.Error.Type)
+}
+
+func TestJunitSink_PartialStreamWithoutContextEvents(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	require.NotPanics(t, func() {
+		sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "pass", 1, timestamp, 0))
+		sink.Emit(event.NewScenarioSkippedEvent(runID, "api/logout", "Logout", "filter", timestamp))
+		sink.Emit(event.NewScenarioEnterEvent(runID, "admin/users", "Users", 1, timestamp))
+		sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+		sink.Emit(event.NewRunEndEvent(runID, "interrupted", 1, 0, 1, 0, timestamp, 0))
+	})
+
+	var testsuites struct {
+		Tests  int `xml:"tests,attr"`
+		Errors int `xml:"errors,attr"`
+		Suites []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(buffer.Bytes(), &testsuites))
+	assert.Equal(t, 3, testsuites.Tests)
+	assert.Equal(t, 1, testsuites.Errors)
+	require.Len(t, testsuites.Suites, 2)
+	assert.Equal(t, "api", testsuites.Suites[0].Name)
+	assert.Equal(t, "admin", testsuites.Suites[1].Name)
+}
+
+type junitDetailCase struct {
+	Failure *struct {
+		Message string `xml:"message,attr"`
+		Body    string `xml:",chardata"`
+	} `xml:"failure"`
+	Error *struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Body    string `xml:",chardata"`
+	} `xml:"error"`
+	SystemOut string `xml:"system-out"`
+	SystemErr string `xml:"system-err"`
+}
+
+func parseDetailCases(t *testing.T, output []byte) []junitDetailCase {
+	var testsuites struct {
+		Suites []struct {
+			Cases []junitDetailCase `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	require.NoError(t, xml.Unmarshal(output, &testsuites))
+	require.Len(t, testsuites.Suites, 1)
+	return testsuites.Suites[0].Cases
+}
+
+func emitFailedLogin(sink Sink, runID string, timestamp time.Time) {
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewScenarioRunStartEvent(runID, "api/login", timestamp))
+	sink.Emit(event.NewOutputEvent(runID, "api/login", "_run", 1, "stdout", "logging in\n"))
+	sink.Emit(event.NewOutputEvent(runID, "api/login", "_run", 2, "stderr", "warning: slow\n"))
+	sink.Emit(event.NewScenarioRunEndEvent(runID, "api/login", 0, 0))
+	sink.Emit(event.NewAssertionStartEvent(runID, "api/login", 0, "assert_equals 0 ${RUN_OUTPUT}/exit_code", timestamp))
+	sink.Emit(event.NewOutputEvent(runID, "api/login", "_assertions/0", 3, "stdout", "PASS: values are equal\n"))
+	sink.Emit(event.NewAssertionEndEvent(runID, "api/login", 0, 0, 1, "", 0))
+	sink.Emit(event.NewAssertionStartEvent(runID, "api/login", 1, "assert_contains welcome ${RUN_OUTPUT}/stdout", timestamp))
+	sink.Emit(event.NewOutputEvent(runID, "api/login", "_assertions/1", 4, "stdout", "FAIL: substring not found\n"))
+	sink.Emit(event.NewAssertionEndEvent(runID, "api/login", 1, 1, 1, "", 0))
+}
+
+func TestJunitSink_FailureCarriesAssertionDetails(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	emitFailedLogin(sink, runID, timestamp)
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 0, 0, timestamp, 0))
+
+	cases := parseDetailCases(t, buffer.Bytes())
+	require.Len(t, cases, 1)
+	require.NotNil(t, cases[0].Failure)
+	assert.Equal(t, "assert_contains welcome ${RUN_OUTPUT}/stdout", cases[0].Failure.Message)
+	assert.Equal(t, `assert_equals 0 ${RUN_OUTPUT}/exit_code (exit 0)
+PASS: values are equal
+
+assert_contains welcome ${RUN_OUTPUT}/stdout (exit 1)
+FAIL: substring not found
+`, cases[0].Failure.Body)
+	assert.Equal(t, "logging in\n", cases[0].SystemOut)
+	assert.Equal(t, "warning: slow\n", cases[0].SystemErr)
+}
+
+func TestJunitSink_RetryResetsAssertionDetails(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	emitFailedLogin(sink, runID, timestamp)
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "retry", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 2, timestamp))
+	sink.Emit(event.NewOutputEvent(runID, "api/login", "_run", 5, "stdout", "second try\n"))
+	sink.Emit(event.NewAssertionStartEvent(runID, "api/login", 0, "assert_equals 1 ${RUN_OUTPUT}/exit_code", timestamp))
+	sink.Emit(event.NewAssertionEndEvent(runID, "api/login", 0, 1, 1, "", 0))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "fail", 2, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 0, 0, timestamp, 0))
+
+	cases := parseDetailCases(t, buffer.Bytes())
+	require.Len(t, cases, 1)
+	require.NotNil(t, cases[0].Failure)
+	assert.Equal(t, "assert_equals 1 ${RUN_OUTPUT}/exit_code", cases[0].Failure.Message)
+	assert.Equal(t, "assert_equals 1 ${RUN_OUTPUT}/exit_code (exit 1)\n", cases[0].Failure.Body)
+	assert.Equal(t, "second try\n", cases[0].SystemOut)
+	assert.Empty(t, cases[0].SystemErr)
+}
+
+func TestJunitSink_PolledAssertionReportsAttempts(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "jobs", "Jobs", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "jobs/finish", "Finish", 1, timestamp))
+	sink.Emit(event.NewAssertionStartEvent(runID, "jobs/finish", 0, "assert_contains done ${RUN_OUTPUT}/stdout", timestamp))
+	sink.Emit(event.NewOutputEvent(runID, "jobs/finish", "_assertions/0", 1, "stdout", "FAIL: substring not found\n"))
+	sink.Emit(event.NewAssertionEndEvent(runID, "jobs/finish", 0, 1, 5, "FAIL: substring not found\n", 0))
+	sink.Emit(event.NewScenarioExitEvent(runID, "jobs/finish", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "jobs", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 0, 0, timestamp, 0))
+
+	cases := parseDetailCases(t, buffer.Bytes())
+	require.Len(t, cases, 1)
+	require.NotNil(t, cases[0].Failure)
+	assert.Equal(t, "assert_contains done ${RUN_OUTPUT}/stdout (exit 1 after 5 attempts)\nFAIL: substring not found\n", cases[0].Failure.Body)
+}
+
+func TestJunitSink_FailureWithoutFailingAssertionKeepsGenericMessage(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 0, 0, timestamp, 0))
+
+	cases := parseDetailCases(t, buffer.Bytes())
+	require.Len(t, cases, 1)
+	require.NotNil(t, cases[0].Failure)
+	assert.Equal(t, "test failed", cases[0].Failure.Message)
+	assert.NotContains(t, buffer.String(), "<system-out>")
+}
+
+func TestJunitSink_RunTimeoutBecomesTimeoutError(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewScenarioRunStartEvent(runID, "api/login", timestamp))
+	sink.Emit(event.NewOutputEvent(runID, "api/login", "_run", 1, "stdout", "waiting\n"))
+	sink.Emit(event.NewTimeoutEvent(runID, "api/login", "run", "5s"))
+	sink.Emit(event.NewScenarioRunEndEvent(runID, "api/login", -1, 0))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 0, 0, timestamp, 0))
+
+	cases := parseDetailCases(t, buffer.Bytes())
+	require.Len(t, cases, 1)
+	assert.Nil(t, cases[0].Failure)
+	require.NotNil(t, cases[0].Error)
+	assert.Equal(t, "timeout", cases[0].Error.Type)
+	assert.Equal(t, "run timed out after 5s", cases[0].Error.Message)
+	assert.Equal(t, "waiting\n", cases[0].SystemOut)
+	assert.Contains(t, buffer.String(), `errors="1"`)
+}
+
+func TestJunitSink_ContextHookTimeoutBecomesTimeoutError(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewJunitSink(buffer)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "2026-01-15_143022"
+
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewTimeoutEvent(runID, "api", "before", "2s"))
+	sink.Emit(event.NewHookEndEvent(runID, "api", "_before", "", -1, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 0, 0, 0, timestamp, 0))
+
+	cases := parseDetailCases(t, buffer.Bytes())
+	require.Len(t, cases, 1)
+	require.NotNil(t, cases[0].Error)
+	assert.Equal(t, "timeout", cases[0].Error.Type)
+	assert.Equal(t, "before timed out after 2s", cases[0].Error.Message)
 }
@@ -0,0 +1,298 @@
+package sink
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"basanos/internal/event"
+	"basanos/internal/spec"
+	"basanos/internal/tree"
+)
+
+type tapNode struct {
+	name      string
+	leaf      bool
+	context   bool
+	children  []*tapNode
+	written   int
+	started   bool
+	done      bool
+	ok        bool
+	directive string
+	failure   *tapDiagnostic
+	bailed    bool
+}
+
+type tapDiagnostic struct {
+	Message    string         `yaml:"message"`
+	Severity   string         `yaml:"severity"`
+	Assertions []tapAssertion `yaml:"assertions,omitempty"`
+}
+
+type tapAssertion struct {
+	Command  string `yaml:"command"`
+	ExitCode int    `yaml:"exit_code"`
+	Attempts int    `yaml:"attempts,omitempty"`
+	Output   string `yaml:"output,omitempty"`
+}
+
+type TapSink struct {
+	writer   io.Writer
+	top      *tapNode
+	nodes    map[string]*tapNode
+	attempts map[string]*attemptDetails
+}
+
+func NewTapSink(writer io.Writer, specTree *tree.SpecTree) Sink {
+	sink := &TapSink{
+		writer:   writer,
+		nodes:    make(map[string]*tapNode),
+		attempts: make(map[string]*attemptDetails),
+	}
+	sink.top = sink.contextNode(specTree)
+	return sink
+}
+
+func (sink *TapSink) contextNode(specTree *tree.SpecTree) *tapNode {
+	node := &tapNode{name: specTree.Context.Name, context: true, ok: true}
+	sink.nodes[specTree.Path] = node
+	node.children = sink.scenarioNodes(specTree.Path, specTree.Context.Scenarios)
+	for _, child := range specTree.Children {
+		node.children = append(node.children, sink.contextNode(child))
+	}
+	return node
+}
+
+func (sink *TapSink) scenarioNodes(basePath string, scenarios []spec.Scenario) []*tapNode {
+	var nodes []*tapNode
+	for _, scenario := range scenarios {
+		scenarioPath := basePath + "/" + scenario.ID
+		node := &tapNode{name: scenario.Name, leaf: scenario.Run != nil, ok: true}
+		if !node.leaf {
+			node.children = sink.scenarioNodes(scenarioPath, scenario.Scenarios)
+		}
+		sink.nodes[scenarioPath] = node
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (sink *TapSink) Emit(incoming any) error {
+	switch typed := incoming.(type) {
+	case *event.RunStartEvent:
+		fmt.Fprintf(sink.writer, "TAP version 14\n%s\n", tapPlan(len(sink.top.children)))
+	case *event.ScenarioEnterEvent:
+		sink.attempts[typed.Path] = &attemptDetails{}
+	case *event.HookEndEvent:
+		sink.handleHookEnd(typed)
+	case *event.TimeoutEvent:
+		if details, exists := sink.attempts[typed.Path]; exists {
+			details.recordTimeout(timeoutMessage(typed))
+		}
+	case *event.AssertionStartEvent:
+		if details, exists := sink.attempts[typed.Path]; exists {
+			details.recordAssertionStart(typed)
+		}
+	case *event.AssertionEndEvent:
+		if details, exists := sink.attempts[typed.Path]; exists {
+			details.recordAssertionEnd(typed)
+		}
+	case *event.OutputEvent:
+		if details, exists := sink.attempts[typed.Path]; exists {
+			details.recordOutput(typed)
+		}
+	case *event.ServiceStopEvent:
+		sink.handleServiceStop(typed)
+	case *event.ScenarioExitEvent:
+		sink.handleScenarioExit(typed)
+	case *event.ScenarioSkippedEvent:
+		sink.handleScenarioSkipped(typed)
+	case *event.ContextExitEvent:
+		if node, exists := sink.nodes[typed.Path]; exists {
+			node.done = true
+			sink.flush()
+		}
+	case *event.RunEndEvent:
+		sink.finishUnfinished(sink.top)
+		sink.flush()
+	}
+	return nil
+}
+
+func (sink *TapSink) handleHookEnd(end *event.HookEndEvent) {
+	if end.ExitCode == 0 {
+		return
+	}
+	if details, exists := sink.attempts[end.Path]; exists {
+		details.recordHookFailure(hookFailureMessage(end.Hook, end.ExitCode))
+		return
+	}
+	sink.failContext(end.Path, hookFailureMessage(end.Hook, end.ExitCode))
+}
+
+func (sink *TapSink) handleServiceStop(stop *event.ServiceStopEvent) {
+	if stop.Reason == "teardown" || stop.Reason == "interrupted" {
+		return
+	}
+	sink.failContext(stop.Path, fmt.Sprintf("service %s stopped: %s", stop.Service, stop.Reason))
+}
+
+func (sink *TapSink) failContext(path, message string) {
+	node, exists := sink.nodes[path]
+	if !exists || !node.context || node.failure != nil {
+		return
+	}
+	node.ok = false
+	node.failure = &tapDiagnostic{Message: message, Severity: "error"}
+}
+
+func (sink *TapSink) handleScenarioExit(exit *event.ScenarioExitEvent) {
+	node, exists := sink.nodes[exit.Path]
+	if !exists || exit.Status == "retry" {
+		return
+	}
+	details := sink.attempts[exit.Path]
+	delete(sink.attempts, exit.Path)
+	node.done = true
+	node.ok = exit.Status == "pass" || exit.Status == "flaky"
+	if !node.ok && details != nil {
+		node.failure = scenarioDiagnostic(exit.Status, details)
+	}
+	sink.flush()
+}
+
+func scenarioDiagnostic(status string, details *attemptDetails) *tapDiagnostic {
+	diagnostic := &tapDiagnostic{Severity: status}
+	switch {
+	case details.timeout != "":
+		diagnostic.Message = details.timeout
+		diagnostic.Severity = "timeout"
+	case status == "error" && details.hookFailure != "":
+		diagnostic.Message = details.hookFailure
+	default:
+		diagnostic.Message = details.failureMessage()
+	}
+	for _, assertion := range details.assertions {
+		entry := tapAssertion{
+			Command:  assertion.command,
+			ExitCode: assertion.exitCode,
+			Output:   assertion.output.String(),
+		}
+		if assertion.attempts > 1 {
+			entry.Attempts = assertion.attempts
+		}
+		diagnostic.Assertions = append(diagnostic.Assertions, entry)
+	}
+	return diagnostic
+}
+
+func (sink *TapSink) handleScenarioSkipped(skipped *event.ScenarioSkippedEvent) {
+	node, exists := sink.nodes[skipped.Path]
+	if !exists {
+		return
+	}
+	node.done = true
+	node.directive = "SKIP " + skipped.Reason
+	sink.flush()
+}
+
+func (sink *TapSink) finishUnfinished(node *tapNode) {
+	for _, child := range node.children {
+		sink.finishUnfinished(child)
+	}
+	if node.leaf && !node.done {
+		node.ok = false
+		node.failure = &tapDiagnostic{Message: "scenario did not finish", Severity: "interrupted"}
+	}
+	node.done = true
+}
+
+func (sink *TapSink) flush() {
+	if !sink.flushChildren(sink.top, "") || !sink.top.done || sink.top.failure == nil || sink.top.bailed {
+		return
+	}
+	fmt.Fprintf(sink.writer, "Bail out! %s: %s\n", escapeTapDescription(sink.top.name), sink.top.failure.Message)
+	sink.top.bailed = true
+}
+
+func tapPlan(count int) string {
+	if count == 0 {
+		return "1..0 # SKIP no scenarios"
+	}
+	return fmt.Sprintf("1..%d", count)
+}
+
+func (sink *TapSink) flushChildren(node *tapNode, indent string) bool {
+	for node.written < len(node.children) {
+		child := node.children[node.written]
+		if !sink.flushChild(child, node.written+1, indent) {
+			return false
+		}
+		if !child.ok {
+			node.ok = false
+		}
+		node.written++
+	}
+	return true
+}
+
+func (sink *TapSink) flushChild(child *tapNode, number int, indent string) bool {
+	if child.leaf {
+		if !child.done {
+			return false
+		}
+		sink.writeTestPoint(child, number, indent)
+		return true
+	}
+	if !child.started {
+		if len(child.children) == 0 && child.directive == "" {
+			child.directive = "SKIP no scenarios"
+		}
+		fmt.Fprintf(sink.writer, "%s# Subtest: %s\n%s    %s\n", indent, child.name, indent, tapPlan(len(child.children)))
+		child.started = true
+	}
+	if !sink.flushChildren(child, indent+"    ") {
+		return false
+	}
+	if child.context && !child.done {
+		return false
+	}
+	sink.writeTestPoint(child, number, indent)
+	return true
+}
+
+func (sink *TapSink) writeTestPoint(node *tapNode, number int, indent string) {
+	status := "ok"
+	if !node.ok {
+		status = "not ok"
+	}
+	line := fmt.Sprintf("%s%s %d - %s", indent, status, number, escapeTapDescription(node.name))
+	if node.directive != "" {
+		line += " # " + node.directive
+	}
+	fmt.Fprintf(sink.writer, "%s\n", line)
+	if node.failure != nil {
+		sink.writeDiagnostic(node.failure, indent+"  ")
+	}
+}
+
+func (sink *TapSink) writeDiagnostic(diagnostic *tapDiagnostic, indent string) {
+	var data strings.Builder
+	encoder := yaml.NewEncoder(&data)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(diagnostic); err != nil {
+		return
+	}
+	fmt.Fprintf(sink.writer, "%s---\n", indent)
+	for _, line := range strings.Split(strings.TrimSuffix(data.String(), "\n"), "\n") {
+		fmt.Fprintf(sink.writer, "%s%s\n", indent, line)
+	}
+	fmt.Fprintf(sink.writer, "%s...\n", indent)
+}
+
+func escapeTapDescription(description string) string {
+	return strings.NewReplacer(`\`, `\\`, "#", `\#`).Replace(description)
+}
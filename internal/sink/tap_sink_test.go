@@ -0,0 +1,314 @@
+package sink
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"basanos/internal/event"
+	"basanos/internal/spec"
+	"basanos/internal/tree"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tapSpecTree() *tree.SpecTree {
+	return &tree.SpecTree{
+		Path: "api",
+		Context: &spec.Context{
+			Name: "API Tests",
+			Scenarios: []spec.Scenario{
+				{ID: "health", Name: "Health check", Run: &spec.RunBlock{Command: "curl health"}},
+				{ID: "auth", Name: "Auth", Scenarios: []spec.Scenario{
+					{ID: "login", Name: "Login", Run: &spec.RunBlock{Command: "curl login"}},
+					{ID: "logout", Name: "Logout", Run: &spec.RunBlock{Command: "curl logout"}},
+				}},
+			},
+		},
+		Children: []*tree.SpecTree{
+			{
+				Path: "api/admin",
+				Context: &spec.Context{
+					Name: "Admin",
+					Scenarios: []spec.Scenario{
+						{ID: "users", Name: "Lists users", Run: &spec.RunBlock{Command: "curl users"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func emitPass(sink Sink, runID, path, name string, timestamp time.Time) {
+	sink.Emit(event.NewScenarioEnterEvent(runID, path, name, 1, timestamp))
+	sink.Emit(event.NewScenarioExitEvent(runID, path, "pass", 1, timestamp, 0))
+}
+
+func TestTapSink_TopLevelPlanCountsRootChildren(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, tapSpecTree())
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "run-1"
+	sink.Emit(event.NewRunStartEvent(runID, timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	emitPass(sink, runID, "api/health", "Health check", timestamp)
+	emitPass(sink, runID, "api/auth/login", "Login", timestamp)
+	sink.Emit(event.NewScenarioSkippedEvent(runID, "api/auth/logout", "Logout", "filter", timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api/admin", "Admin", timestamp))
+	emitPass(sink, runID, "api/admin/users", "Lists users", timestamp)
+	sink.Emit(event.NewContextExitEvent(runID, "api/admin", timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "pass", 2, 0, 1, 0, timestamp, 0))
+
+	assert.Equal(t, `TAP version 14
+1..3
+ok 1 - Health check
+# Subtest: Auth
+    1..2
+    ok 1 - Login
+    ok 2 - Logout # SKIP filter
+ok 2 - Auth
+# Subtest: Admin
+    1..1
+    ok 1 - Lists users
+ok 3 - Admin
+`, buffer.String())
+}
+
+func TestTapSink_StreamsResultsBeforeTheRunEnds(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, tapSpecTree())
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	sink.Emit(event.NewRunStartEvent("run-1", timestamp))
+	sink.Emit(event.NewContextEnterEvent("run-1", "api", "API Tests", timestamp))
+	emitPass(sink, "run-1", "api/health", "Health check", timestamp)
+
+	assert.Equal(t, `TAP version 14
+1..3
+ok 1 - Health check
+# Subtest: Auth
+    1..2
+`, buffer.String())
+}
+
+func TestTapSink_FailureHasYamlDiagnostic(t *testing.T) {
+	specTree := &tree.SpecTree{
+		Path: "api",
+		Context: &spec.Context{
+			Name: "API Tests",
+			Scenarios: []spec.Scenario{
+				{ID: "login", Name: "Login", Run: &spec.RunBlock{Command: "curl login"}},
+			},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, specTree)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "run-1"
+	sink.Emit(event.NewRunStartEvent(runID, timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/login", "Login", 1, timestamp))
+	sink.Emit(event.NewAssertionStartEvent(runID, "api/login", 0, "assert_contains welcome ${RUN_OUTPUT}/stdout", timestamp))
+	sink.Emit(event.NewOutputEvent(runID, "api/login", "_assertions/0", 1, "stdout", "FAIL: substring not found\n"))
+	sink.Emit(event.NewAssertionEndEvent(runID, "api/login", 0, 1, 1, "", 0))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/login", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 1, 0, 0, timestamp, 0))
+
+	assert.Equal(t, `TAP version 14
+1..1
+not ok 1 - Login
+  ---
+  message: assert_contains welcome ${RUN_OUTPUT}/stdout
+  severity: fail
+  assertions:
+    - command: assert_contains welcome ${RUN_OUTPUT}/stdout
+      exit_code: 1
+      output: |
+        FAIL: substring not found
+  ...
+`, buffer.String())
+}
+
+func TestTapSink_PolledAssertionDiagnosticIncludesAttempts(t *testing.T) {
+	specTree := &tree.SpecTree{
+		Path: "api",
+		Context: &spec.Context{
+			Name: "API Tests",
+			Scenarios: []spec.Scenario{
+				{ID: "ready", Name: "Ready", Run: &spec.RunBlock{Command: "start server"}},
+			},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, specTree)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "run-1"
+	sink.Emit(event.NewRunStartEvent(runID, timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/ready", "Ready", 1, timestamp))
+	sink.Emit(event.NewAssertionStartEvent(runID, "api/ready", 0, "assert_contains ready ${RUN_OUTPUT}/stdout", timestamp))
+	sink.Emit(event.NewAssertionEndEvent(runID, "api/ready", 0, 1, 30, "FAIL: substring not found\n", 0))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/ready", "fail", 1, timestamp, 0))
+
+	assert.Contains(t, buffer.String(), `    - command: assert_contains ready ${RUN_OUTPUT}/stdout
+      exit_code: 1
+      attempts: 30
+`)
+}
+
+func TestTapSink_TimeoutAndHookFailureDiagnostics(t *testing.T) {
+	specTree := &tree.SpecTree{
+		Path: "api",
+		Context: &spec.Context{
+			Name: "API Tests",
+			Scenarios: []spec.Scenario{
+				{ID: "slow", Name: "Slow", Run: &spec.RunBlock{Command: "sleep 10"}},
+				{ID: "setup", Name: "Setup", Run: &spec.RunBlock{Command: "true"}},
+			},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, specTree)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "run-1"
+	sink.Emit(event.NewRunStartEvent(runID, timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/slow", "Slow", 1, timestamp))
+	sink.Emit(event.NewTimeoutEvent(runID, "api/slow", "run", "5s"))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/slow", "fail", 1, timestamp, 0))
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/setup", "Setup", 1, timestamp))
+	sink.Emit(event.NewHookEndEvent(runID, "api/setup", "_before_each", "", 2, 0))
+	sink.Emit(event.NewScenarioExitEvent(runID, "api/setup", "error", 1, timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 0, 2, 0, 0, timestamp, 0))
+
+	assert.Contains(t, buffer.String(), `not ok 1 - Slow
+  ---
+  message: run timed out after 5s
+  severity: timeout
+  ...
+`)
+	assert.Contains(t, buffer.String(), `not ok 2 - Setup
+  ---
+  message: _before_each hook exited with code 2
+  severity: error
+  ...
+`)
+}
+
+func TestTapSink_ContextHookFailureFailsTheSubtest(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, tapSpecTree())
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "run-1"
+	sink.Emit(event.NewRunStartEvent(runID, timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	emitPass(sink, runID, "api/health", "Health check", timestamp)
+	emitPass(sink, runID, "api/auth/login", "Login", timestamp)
+	emitPass(sink, runID, "api/auth/logout", "Logout", timestamp)
+	sink.Emit(event.NewContextEnterEvent(runID, "api/admin", "Admin", timestamp))
+	emitPass(sink, runID, "api/admin/users", "Lists users", timestamp)
+	sink.Emit(event.NewHookEndEvent(runID, "api/admin", "_after", "", 1, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api/admin", timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 4, 0, 0, 0, timestamp, 0))
+
+	assert.True(t, strings.HasSuffix(buffer.String(), `not ok 3 - Admin
+  ---
+  message: _after hook exited with code 1
+  severity: error
+  ...
+`))
+}
+
+func TestTapSink_RootContextHookFailureBailsOut(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, tapSpecTree())
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "run-1"
+	sink.Emit(event.NewRunStartEvent(runID, timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	emitPass(sink, runID, "api/health", "Health check", timestamp)
+	emitPass(sink, runID, "api/auth/login", "Login", timestamp)
+	emitPass(sink, runID, "api/auth/logout", "Logout", timestamp)
+	sink.Emit(event.NewContextEnterEvent(runID, "api/admin", "Admin", timestamp))
+	emitPass(sink, runID, "api/admin/users", "Lists users", timestamp)
+	sink.Emit(event.NewContextExitEvent(runID, "api/admin", timestamp, 0))
+	sink.Emit(event.NewHookEndEvent(runID, "api", "_after", "", 1, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "fail", 4, 0, 0, 0, timestamp, 0))
+
+	assert.True(t, strings.HasSuffix(buffer.String(), "ok 3 - Admin\nBail out! API Tests: _after hook exited with code 1\n"))
+}
+
+func TestTapSink_EmptyGroupHasSkippedPlan(t *testing.T) {
+	specTree := &tree.SpecTree{
+		Path:    "api",
+		Context: &spec.Context{Name: "API Tests"},
+		Children: []*tree.SpecTree{
+			{Path: "api/admin", Context: &spec.Context{Name: "Admin"}},
+		},
+	}
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, specTree)
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "run-1"
+	sink.Emit(event.NewRunStartEvent(runID, timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api/admin", "Admin", timestamp))
+	sink.Emit(event.NewContextExitEvent(runID, "api/admin", timestamp, 0))
+	sink.Emit(event.NewContextExitEvent(runID, "api", timestamp, 0))
+	sink.Emit(event.NewRunEndEvent(runID, "pass", 0, 0, 0, 0, timestamp, 0))
+
+	assert.Equal(t, `TAP version 14
+1..1
+# Subtest: Admin
+    1..0 # SKIP no scenarios
+ok 1 - Admin # SKIP no scenarios
+`, buffer.String())
+}
+
+func TestTapSink_EmptyRootHasSkippedPlan(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, &tree.SpecTree{Path: "api", Context: &spec.Context{Name: "API Tests"}})
+
+	sink.Emit(event.NewRunStartEvent("run-1", time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)))
+
+	assert.Equal(t, "TAP version 14\n1..0 # SKIP no scenarios\n", buffer.String())
+}
+
+func TestTapSink_UnfinishedScenariosFailAtRunEnd(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	sink := NewTapSink(buffer, tapSpecTree())
+
+	timestamp := time.Date(2026, 1, 15, 14, 30, 22, 0, time.UTC)
+	runID := "run-1"
+	sink.Emit(event.NewRunStartEvent(runID, timestamp))
+	sink.Emit(event.NewContextEnterEvent(runID, "api", "API Tests", timestamp))
+	emitPass(sink, runID, "api/health", "Health check", timestamp)
+	sink.Emit(event.NewScenarioEnterEvent(runID, "api/auth/login", "Login", 1, timestamp))
+	sink.Emit(event.NewRunEndEvent(runID, "interrupted", 1, 0, 0, 0, timestamp, 0))
+
+	assert.Contains(t, buffer.String(), `    not ok 1 - Login
+      ---
+      message: scenario did not finish
+      severity: interrupted
+      ...
+`)
+	assert.Contains(t, buffer.String(), "\nnot ok 2 - Auth\n")
+	assert.Contains(t, buffer.String(), "\nnot ok 3 - Admin\n")
+}
+
+func TestTapSink_EscapesHashInDescriptions(t *testing.T) {
+	assert.Equal(t, `issue \#42 with a \\ backslash`, escapeTapDescription(`issue #42 with a \ backslash`))
+}
@@ -1,9 +1,9 @@
 package sinkio
 
 type FileSinkWriter interface {
-	SetCurrentPath(path string)
-	SetCurrentPhase(phase string)
 	WriteExitCode(path, phase string, code int) error
-	AppendOutput(stream, data string) error
-	EnsureOutput(stream string) error
+	AppendOutput(path, phase, stream, data string) error
+	EnsureOutput(path, phase, stream string) error
+	ReplaceOutput(path, phase, stream, data string) error
+	ClearOutput(path string) error
 }
@@ -10,39 +10,78 @@ type Hook struct {
 }
 
 type RunBlock struct {
-	Command string `yaml:"command"`
-	Timeout string `yaml:"timeout"`
+	Command    string `yaml:"command"`
+	Timeout    string `yaml:"timeout"`
+	Retries    int    `yaml:"retries"`
+	RetryDelay string `yaml:"retry_delay"`
 }
 
 type Assertion struct {
-	Command string `yaml:"command"`
-	Timeout string `yaml:"timeout"`
+	Command  string      `yaml:"command"`
+	Timeout  string      `yaml:"timeout"`
+	Within   string      `yaml:"within"`
+	Interval string      `yaml:"interval"`
+	Probe    string      `yaml:"probe"`
+	Not      bool        `yaml:"not"`
+	AnyOf    []Assertion `yaml:"any_of"`
+	AllOf    []Assertion `yaml:"all_of"`
+}
+
+type Readiness struct {
+	TCP      string `yaml:"tcp"`
+	HTTP     string `yaml:"http"`
+	Log      string `yaml:"log"`
+	Command  string `yaml:"command"`
+	Interval string `yaml:"interval"`
+}
+
+type Service struct {
+	Name    string            `yaml:"name"`
+	Run     string            `yaml:"run"`
+	Env     map[string]string `yaml:"env"`
+	Ready   *Readiness        `yaml:"ready"`
+	Timeout string            `yaml:"timeout"`
+}
+
+type NormalizeRule struct {
+	Preset  string `yaml:"preset"`
+	Pattern string `yaml:"pattern"`
+	Replace string `yaml:"replace"`
 }
 
 type Scenario struct {
-	ID         string            `yaml:"id"`
-	Name       string            `yaml:"name"`
-	Env        map[string]string `yaml:"env"`
-	OnFailure  string            `yaml:"on_failure"`
-	Before     *Hook             `yaml:"before"`
-	After      *Hook             `yaml:"after"`
-	BeforeEach *Hook             `yaml:"before_each"`
-	AfterEach  *Hook             `yaml:"after_each"`
-	Run        *RunBlock         `yaml:"run"`
-	Assertions []Assertion       `yaml:"assertions"`
-	Scenarios  []Scenario        `yaml:"scenarios"`
+	ID         string              `yaml:"id"`
+	Name       string              `yaml:"name"`
+	Env        map[string]string   `yaml:"env"`
+	Matrix     []map[string]string `yaml:"matrix"`
+	OnFailure  string              `yaml:"on_failure"`
+	Parallel   bool                `yaml:"parallel"`
+	Retries    int                 `yaml:"retries"`
+	RetryDelay string              `yaml:"retry_delay"`
+	Normalize  []NormalizeRule     `yaml:"normalize"`
+	Before     *Hook               `yaml:"before"`
+	After      *Hook               `yaml:"after"`
+	BeforeEach *Hook               `yaml:"before_each"`
+	AfterEach  *Hook               `yaml:"after_each"`
+	Run        *RunBlock           `yaml:"run"`
+	Assertions []Assertion         `yaml:"assertions"`
+	Scenarios  []Scenario          `yaml:"scenarios"`
 }
 
 type Context struct {
-	Name        string            `yaml:"name"`
-	Description string            `yaml:"description"`
-	Env         map[string]string `yaml:"env"`
-	OnFailure   string            `yaml:"on_failure"`
-	Before      *Hook             `yaml:"before"`
-	After       *Hook             `yaml:"after"`
-	BeforeEach  *Hook             `yaml:"before_each"`
-	AfterEach   *Hook             `yaml:"after_each"`
-	Scenarios   []Scenario        `yaml:"scenarios"`
+	Name             string            `yaml:"name"`
+	Description      string            `yaml:"description"`
+	AllowUnknownKeys bool              `yaml:"allow_unknown_keys"`
+	Env              map[string]string `yaml:"env"`
+	OnFailure        string            `yaml:"on_failure"`
+	Parallel         bool              `yaml:"parallel"`
+	Normalize        []NormalizeRule   `yaml:"normalize"`
+	Services         []Service         `yaml:"services"`
+	Before           *Hook             `yaml:"before"`
+	After            *Hook             `yaml:"after"`
+	BeforeEach       *Hook             `yaml:"before_each"`
+	AfterEach        *Hook             `yaml:"after_each"`
+	Scenarios        []Scenario        `yaml:"scenarios"`
 }
 
 func ParseContext(data []byte) (*Context, error) {
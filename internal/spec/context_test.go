@@ -93,6 +93,77 @@ after_each:
 	assert.Equal(t, "1s", ctx.AfterEach.Timeout)
 }
 
+func TestParseContext_Parallel(t *testing.T) {
+	yaml := `
+parallel: true
+scenarios:
+  - id: group
+    parallel: true
+`
+	ctx, err := ParseContext([]byte(yaml))
+
+	require.NoError(t, err)
+	assert.True(t, ctx.Parallel)
+	assert.True(t, ctx.Scenarios[0].Parallel)
+}
+
+func TestParseContext_Normalize(t *testing.T) {
+	yaml := `
+normalize:
+  - preset: timestamps
+scenarios:
+  - id: login
+    normalize:
+      - pattern: 'session=[0-9a-f]+'
+        replace: 'session=<SESSION>'
+`
+	ctx, err := ParseContext([]byte(yaml))
+
+	require.NoError(t, err)
+	assert.Equal(t, []NormalizeRule{{Preset: "timestamps"}}, ctx.Normalize)
+	assert.Equal(t, []NormalizeRule{{Pattern: "session=[0-9a-f]+", Replace: "session=<SESSION>"}}, ctx.Scenarios[0].Normalize)
+}
+
+func TestParseContext_Retries(t *testing.T) {
+	yaml := `
+scenarios:
+  - id: flaky
+    retries: 2
+    retry_delay: 100ms
+    run:
+      command: ./agent.sh
+      retries: 3
+      retry_delay: 1s
+`
+	ctx, err := ParseContext([]byte(yaml))
+
+	require.NoError(t, err)
+	scenario := ctx.Scenarios[0]
+	assert.Equal(t, 2, scenario.Retries)
+	assert.Equal(t, "100ms", scenario.RetryDelay)
+	assert.Equal(t, 3, scenario.Run.Retries)
+	assert.Equal(t, "1s", scenario.Run.RetryDelay)
+}
+
+func TestParseContext_Matrix(t *testing.T) {
+	yaml := `
+scenarios:
+  - id: login
+    matrix:
+      - ROLE: admin
+        EXPECTED: admin.json
+      - id: anonymous
+        ROLE: ""
+`
+	ctx, err := ParseContext([]byte(yaml))
+
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"ROLE": "admin", "EXPECTED": "admin.json"},
+		{"id": "anonymous", "ROLE": ""},
+	}, ctx.Scenarios[0].Matrix)
+}
+
 func TestParseContext_Scenarios(t *testing.T) {
 	yaml := `
 scenarios:
@@ -209,3 +280,46 @@ scenarios:
 	assert.Equal(t, "reset_state.sh", ctx.Scenarios[0].BeforeEach.Run)
 	assert.Equal(t, "cleanup.sh", ctx.Scenarios[0].AfterEach.Run)
 }
+
+func TestParseContext_AllowUnknownKeys(t *testing.T) {
+	yaml := `
+name: "Spec"
+allow_unknown_keys: true
+befor_each:
+  run: echo setup
+`
+	ctx, err := ParseContext([]byte(yaml))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Spec", ctx.Name)
+	assert.Nil(t, ctx.BeforeEach)
+}
+
+func TestParseContext_Services(t *testing.T) {
+	yaml := `
+services:
+  - name: db
+    run: postgres -p 5433
+    env:
+      DATABASE_URL: "postgres://localhost:5433/test"
+    ready:
+      tcp: "5433"
+      interval: 200ms
+    timeout: 20s
+  - name: api
+    run: ./server
+    ready:
+      log: "listening on"
+`
+	ctx, err := ParseContext([]byte(yaml))
+
+	require.NoError(t, err)
+	require.Len(t, ctx.Services, 2)
+	assert.Equal(t, "db", ctx.Services[0].Name)
+	assert.Equal(t, "postgres -p 5433", ctx.Services[0].Run)
+	assert.Equal(t, "postgres://localhost:5433/test", ctx.Services[0].Env["DATABASE_URL"])
+	assert.Equal(t, "5433", ctx.Services[0].Ready.TCP)
+	assert.Equal(t, "200ms", ctx.Services[0].Ready.Interval)
+	assert.Equal(t, "20s", ctx.Services[0].Timeout)
+	assert.Equal(t, "listening on", ctx.Services[1].Ready.Log)
+}
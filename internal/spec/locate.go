@@ -0,0 +1,115 @@
+package spec
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var yamlLinePattern = regexp.MustCompile(`line (\d+): (.*)`)
+
+type pathSegment struct {
+	key     string
+	indices []int
+}
+
+func parsePath(path string) []pathSegment {
+	var segments []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		segment := pathSegment{key: key}
+		for _, index := range strings.Split(rest, "[") {
+			if value, err := strconv.Atoi(strings.TrimSuffix(index, "]")); err == nil {
+				segment.indices = append(segment.indices, value)
+			}
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func sequenceItem(node *yaml.Node, index int) *yaml.Node {
+	if node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+		return nil
+	}
+	return node.Content[index]
+}
+
+func locate(root *yaml.Node, path string) (int, int) {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, segment := range parsePath(path) {
+		next := mappingValue(node, segment.key)
+		for _, index := range segment.indices {
+			if next == nil {
+				break
+			}
+			next = sequenceItem(next, index)
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node.Line, node.Column
+}
+
+func yamlErrors(err error, filePath string) []ValidationError {
+	messages := []string{err.Error()}
+	var typeError *yaml.TypeError
+	if errors.As(err, &typeError) {
+		messages = typeError.Errors
+	}
+	var result []ValidationError
+	for _, message := range messages {
+		validationError := ValidationError{File: filePath, Message: message}
+		if match := yamlLinePattern.FindStringSubmatch(message); match != nil {
+			validationError.Line, _ = strconv.Atoi(match[1])
+			validationError.Message = match[2]
+		}
+		result = append(result, validationError)
+	}
+	return result
+}
+
+func ValidateDocument(data []byte, filePath string) (*Context, []ValidationError) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, yamlErrors(err, filePath)
+	}
+	var ctx Context
+	if err := root.Decode(&ctx); err != nil {
+		return nil, yamlErrors(err, filePath)
+	}
+	validationErrors := Validate(&ctx, filePath)
+	for i := range validationErrors {
+		validationErrors[i].Line, validationErrors[i].Column = locate(&root, validationErrors[i].Path)
+	}
+	if !ctx.AllowUnknownKeys && len(root.Content) > 0 {
+		validationErrors = append(validationErrors, checkKnownKeys(root.Content[0], reflect.TypeOf(ctx), "", filePath)...)
+	}
+	slices.SortStableFunc(validationErrors, func(left, right ValidationError) int {
+		return cmp.Compare(left.Line, right.Line)
+	})
+	return &ctx, validationErrors
+}
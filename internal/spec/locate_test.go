@@ -0,0 +1,151 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDocument_ReturnsContextWhenValid(t *testing.T) {
+	ctx, errors := ValidateDocument([]byte(`name: "Valid"`), "context.yaml")
+
+	assert.Empty(t, errors)
+	require.NotNil(t, ctx)
+	assert.Equal(t, "Valid", ctx.Name)
+}
+
+func TestValidateDocument_LocatesInvalidValue(t *testing.T) {
+	yaml := `name: "Spec"
+scenarios:
+  - id: slow
+    run:
+      command: sleep 1
+      timeout: forever
+`
+	_, errors := ValidateDocument([]byte(yaml), "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, 6, errors[0].Line)
+	assert.Equal(t, 16, errors[0].Column)
+}
+
+func TestValidateDocument_LocatesMissingKeyAtEnclosingNode(t *testing.T) {
+	yaml := `name: "Spec"
+scenarios:
+  - id: first
+    run:
+      command: echo first
+  - name: "No ID"
+    run:
+      command: echo second
+`
+	_, errors := ValidateDocument([]byte(yaml), "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[1].id", errors[0].Path)
+	assert.Equal(t, 6, errors[0].Line)
+	assert.Equal(t, 5, errors[0].Column)
+}
+
+func TestValidateDocument_CollectsEveryError(t *testing.T) {
+	yaml := `on_failure: explode
+before:
+  timeout: 1s
+scenarios:
+  - run:
+      command: echo
+`
+	_, errors := ValidateDocument([]byte(yaml), "context.yaml")
+
+	require.Len(t, errors, 3)
+	assert.Equal(t, 1, errors[0].Line)
+	assert.Equal(t, 3, errors[1].Line)
+	assert.Equal(t, 5, errors[2].Line)
+}
+
+func TestValidateDocument_ReportsSyntaxErrorLine(t *testing.T) {
+	yaml := `name: "Spec"
+scenarios:
+  - id: broken
+   run: [
+`
+	ctx, errors := ValidateDocument([]byte(yaml), "context.yaml")
+
+	assert.Nil(t, ctx)
+	require.Len(t, errors, 1)
+	assert.Equal(t, "context.yaml", errors[0].File)
+	assert.Greater(t, errors[0].Line, 0)
+}
+
+func TestValidateDocument_ReportsTypeErrorLine(t *testing.T) {
+	yaml := `name: "Spec"
+scenarios:
+  - id: flaky
+    retries: lots
+`
+	_, errors := ValidateDocument([]byte(yaml), "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, 4, errors[0].Line)
+	assert.Contains(t, errors[0].Message, "lots")
+}
+
+func TestValidateDocument_RejectsUnknownKeys(t *testing.T) {
+	yaml := `name: "Spec"
+befor_each:
+  run: echo setup
+scenarios:
+  - id: typo
+    run:
+      command: echo hello
+    assertion:
+      - command: assert_contains hello
+`
+	_, errors := ValidateDocument([]byte(yaml), "context.yaml")
+
+	require.Len(t, errors, 2)
+	assert.Equal(t, "befor_each", errors[0].Path)
+	assert.Equal(t, 2, errors[0].Line)
+	assert.Equal(t, 1, errors[0].Column)
+	assert.Equal(t, `unknown key "befor_each", did you mean "before_each"?`, errors[0].Message)
+	assert.Equal(t, "scenarios[0].assertion", errors[1].Path)
+	assert.Equal(t, 8, errors[1].Line)
+	assert.Equal(t, `unknown key "assertion", did you mean "assertions"?`, errors[1].Message)
+}
+
+func TestValidateDocument_RejectsUnknownKeysInNestedTypes(t *testing.T) {
+	yaml := `scenarios:
+  - id: group
+    scenarios:
+      - id: leaf
+        run:
+          command: echo
+          timeot: 1s
+        assertions:
+          - command: assert_contains x
+            expected: y
+`
+	_, errors := ValidateDocument([]byte(yaml), "context.yaml")
+
+	require.Len(t, errors, 2)
+	assert.Equal(t, "scenarios[0].scenarios[0].run.timeot", errors[0].Path)
+	assert.Equal(t, `unknown key "timeot", did you mean "timeout"?`, errors[0].Message)
+	assert.Equal(t, "scenarios[0].scenarios[0].assertions[0].expected", errors[1].Path)
+	assert.Equal(t, `unknown key "expected"`, errors[1].Message)
+}
+
+func TestValidateDocument_AllowUnknownKeysOptsOut(t *testing.T) {
+	yaml := `allow_unknown_keys: true
+future_setting: enabled
+scenarios:
+  - id: test
+    run:
+      command: echo
+      sandbox: strict
+`
+	ctx, errors := ValidateDocument([]byte(yaml), "context.yaml")
+
+	assert.Empty(t, errors)
+	assert.True(t, ctx.AllowUnknownKeys)
+}
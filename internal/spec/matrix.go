@@ -0,0 +1,66 @@
+package spec
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+func MatrixRowID(row map[string]string) string {
+	if id, ok := row["id"]; ok {
+		return id
+	}
+	var values []string
+	for _, key := range slices.Sorted(maps.Keys(row)) {
+		values = append(values, row[key])
+	}
+	return strings.Join(values, ",")
+}
+
+func matrixIDs(scenario Scenario) []string {
+	if len(scenario.Matrix) == 0 {
+		return []string{scenario.ID}
+	}
+	var ids []string
+	for _, row := range scenario.Matrix {
+		ids = append(ids, scenario.ID+"["+MatrixRowID(row)+"]")
+	}
+	return ids
+}
+
+func expandRow(scenario Scenario, row map[string]string) Scenario {
+	rowID := MatrixRowID(row)
+	expanded := scenario
+	expanded.ID = scenario.ID + "[" + rowID + "]"
+	expanded.Name = scenario.Name + " [" + rowID + "]"
+	expanded.Matrix = nil
+	expanded.Env = maps.Clone(scenario.Env)
+	if expanded.Env == nil {
+		expanded.Env = make(map[string]string)
+	}
+	for key, value := range row {
+		if key != "id" {
+			expanded.Env[key] = value
+		}
+	}
+	return expanded
+}
+
+func expandScenarios(scenarios []Scenario) []Scenario {
+	var expanded []Scenario
+	for _, scenario := range scenarios {
+		scenario.Scenarios = expandScenarios(scenario.Scenarios)
+		if len(scenario.Matrix) == 0 {
+			expanded = append(expanded, scenario)
+			continue
+		}
+		for _, row := range scenario.Matrix {
+			expanded = append(expanded, expandRow(scenario, row))
+		}
+	}
+	return expanded
+}
+
+func ExpandMatrix(ctx *Context) {
+	ctx.Scenarios = expandScenarios(ctx.Scenarios)
+}
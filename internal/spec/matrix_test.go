@@ -0,0 +1,75 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixRowID_UsesExplicitID(t *testing.T) {
+	assert.Equal(t, "admin", MatrixRowID(map[string]string{"id": "admin", "ROLE": "root"}))
+}
+
+func TestMatrixRowID_JoinsValuesInKeyOrder(t *testing.T) {
+	assert.Equal(t, "json,admin", MatrixRowID(map[string]string{"ROLE": "admin", "FORMAT": "json"}))
+}
+
+func TestExpandMatrix_CreatesOneLeafPerRow(t *testing.T) {
+	ctx := &Context{
+		Scenarios: []Scenario{{
+			ID:   "login",
+			Name: "Login",
+			Env:  map[string]string{"HOST": "localhost"},
+			Run:  &RunBlock{Command: "login ${ROLE}"},
+			Matrix: []map[string]string{
+				{"ROLE": "admin"},
+				{"id": "anonymous", "ROLE": ""},
+			},
+		}},
+	}
+
+	ExpandMatrix(ctx)
+
+	require.Len(t, ctx.Scenarios, 2)
+	assert.Equal(t, "login[admin]", ctx.Scenarios[0].ID)
+	assert.Equal(t, "Login [admin]", ctx.Scenarios[0].Name)
+	assert.Equal(t, map[string]string{"HOST": "localhost", "ROLE": "admin"}, ctx.Scenarios[0].Env)
+	assert.Nil(t, ctx.Scenarios[0].Matrix)
+	assert.Equal(t, "login[anonymous]", ctx.Scenarios[1].ID)
+	assert.Equal(t, map[string]string{"HOST": "localhost", "ROLE": ""}, ctx.Scenarios[1].Env)
+}
+
+func TestExpandMatrix_ExpandsGroupsAndNestedScenarios(t *testing.T) {
+	ctx := &Context{
+		Scenarios: []Scenario{{
+			ID:     "api",
+			Matrix: []map[string]string{{"VERSION": "v1"}, {"VERSION": "v2"}},
+			Scenarios: []Scenario{{
+				ID:     "get",
+				Run:    &RunBlock{Command: "curl /${VERSION}/${RESOURCE}"},
+				Matrix: []map[string]string{{"RESOURCE": "users"}},
+			}},
+		}},
+	}
+
+	ExpandMatrix(ctx)
+
+	require.Len(t, ctx.Scenarios, 2)
+	assert.Equal(t, "api[v1]", ctx.Scenarios[0].ID)
+	assert.Equal(t, "api[v2]", ctx.Scenarios[1].ID)
+	require.Len(t, ctx.Scenarios[1].Scenarios, 1)
+	assert.Equal(t, "get[users]", ctx.Scenarios[1].Scenarios[0].ID)
+}
+
+func TestExpandMatrix_LeavesPlainScenariosAlone(t *testing.T) {
+	ctx := &Context{
+		Scenarios: []Scenario{{ID: "health", Run: &RunBlock{Command: "curl /health"}}},
+	}
+
+	ExpandMatrix(ctx)
+
+	require.Len(t, ctx.Scenarios, 1)
+	assert.Equal(t, "health", ctx.Scenarios[0].ID)
+	assert.Nil(t, ctx.Scenarios[0].Env)
+}
@@ -0,0 +1,53 @@
+package spec
+
+import (
+	"regexp"
+	"slices"
+)
+
+var normalizePresets = map[string]NormalizeRule{
+	"timestamps": {
+		Pattern: `\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`,
+		Replace: "<TIMESTAMP>",
+	},
+	"uuids": {
+		Pattern: `\b[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}\b`,
+		Replace: "<UUID>",
+	},
+	"pids": {
+		Pattern: `(?i)\b(pid[ =:]+)\d+`,
+		Replace: "${1}<PID>",
+	},
+	"temp_paths": {
+		Pattern: `(/private)?/(tmp|var/folders)(/[^\s/'"]+)+`,
+		Replace: "<TMP>",
+	},
+}
+
+func NormalizePresetNames() []string {
+	names := make([]string, 0, len(normalizePresets))
+	for name := range normalizePresets {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func resolveNormalizeRule(rule NormalizeRule) NormalizeRule {
+	if preset, ok := normalizePresets[rule.Preset]; ok {
+		return preset
+	}
+	return rule
+}
+
+func Normalize(text string, rules []NormalizeRule) string {
+	for _, rule := range rules {
+		rule = resolveNormalizeRule(rule)
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			continue
+		}
+		text = pattern.ReplaceAllString(text, rule.Replace)
+	}
+	return text
+}
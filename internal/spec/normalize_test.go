@@ -0,0 +1,45 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize_Presets(t *testing.T) {
+	tests := []struct {
+		preset   string
+		input    string
+		expected string
+	}{
+		{"timestamps", "started 2026-01-15T14:30:22.123Z, done 2026-01-15 14:31:00+02:00", "started <TIMESTAMP>, done <TIMESTAMP>"},
+		{"uuids", "id=3F2504E0-4F89-11D3-9A0C-0305E82C3301 ok", "id=<UUID> ok"},
+		{"pids", "worker pid=4312 and PID: 77", "worker pid=<PID> and PID: <PID>"},
+		{"temp_paths", "wrote /tmp/agent-x8Yz/out.txt and /var/folders/ab/T/cd", "wrote <TMP> and <TMP>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.preset, func(t *testing.T) {
+			assert.Equal(t, test.expected, Normalize(test.input, []NormalizeRule{{Preset: test.preset}}))
+		})
+	}
+}
+
+func TestNormalize_PatternRulesApplyInOrder(t *testing.T) {
+	rules := []NormalizeRule{
+		{Pattern: `took [0-9]+ms`, Replace: "took <N>ms"},
+		{Pattern: `<N>`, Replace: "N"},
+	}
+
+	assert.Equal(t, "request took Nms\n", Normalize("request took 152ms\n", rules))
+}
+
+func TestNormalize_PatternReplacementExpandsGroups(t *testing.T) {
+	rules := []NormalizeRule{{Pattern: `(session)=[0-9a-f]+`, Replace: "${1}=<ID>"}}
+
+	assert.Equal(t, "session=<ID>", Normalize("session=9f86d081", rules))
+}
+
+func TestNormalize_WithoutRules_ReturnsTextUnchanged(t *testing.T) {
+	assert.Equal(t, "2026-01-15T14:30:22Z", Normalize("2026-01-15T14:30:22Z", nil))
+}
@@ -0,0 +1,106 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+func yamlFields(structType reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func levenshtein(left, right string) int {
+	previous := make([]int, len(right)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(left); i++ {
+		current := make([]int, len(right)+1)
+		current[0] = i
+		for j := 1; j <= len(right); j++ {
+			cost := 1
+			if left[i-1] == right[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(right)]
+}
+
+func suggestField(key string, fields map[string]reflect.Type) string {
+	best, bestDistance := "", 0
+	for name := range fields {
+		distance := levenshtein(key, name)
+		if distance > max(2, len(name)/3) {
+			continue
+		}
+		if best == "" || distance < bestDistance || (distance == bestDistance && name < best) {
+			best, bestDistance = name, distance
+		}
+	}
+	return best
+}
+
+func unknownKeyMessage(key string, fields map[string]reflect.Type) string {
+	if suggestion := suggestField(key, fields); suggestion != "" {
+		return fmt.Sprintf("unknown key %q, did you mean %q?", key, suggestion)
+	}
+	return fmt.Sprintf("unknown key %q", key)
+}
+
+func checkKnownKeys(node *yaml.Node, nodeType reflect.Type, path, filePath string) []ValidationError {
+	for nodeType.Kind() == reflect.Pointer {
+		nodeType = nodeType.Elem()
+	}
+	var errors []ValidationError
+	switch {
+	case nodeType.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := yamlFields(nodeType)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := joinPath(path, key.Value)
+			fieldType, known := fields[key.Value]
+			if !known {
+				errors = append(errors, ValidationError{
+					File:    filePath,
+					Line:    key.Line,
+					Column:  key.Column,
+					Path:    keyPath,
+					Message: unknownKeyMessage(key.Value, fields),
+				})
+				continue
+			}
+			errors = append(errors, checkKnownKeys(value, fieldType, keyPath, filePath)...)
+		}
+	case nodeType.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for index, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, index)
+			errors = append(errors, checkKnownKeys(item, nodeType.Elem(), itemPath, filePath)...)
+		}
+	}
+	return errors
+}
@@ -0,0 +1,30 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYamlFields_UsesTagNames(t *testing.T) {
+	fields := yamlFields(reflect.TypeOf(Hook{}))
+
+	assert.Contains(t, fields, "run")
+	assert.Contains(t, fields, "timeout")
+	assert.NotContains(t, fields, "Run")
+}
+
+func TestLevenshtein(t *testing.T) {
+	assert.Equal(t, 0, levenshtein("run", "run"))
+	assert.Equal(t, 1, levenshtein("befor_each", "before_each"))
+	assert.Equal(t, 1, levenshtein("assertion", "assertions"))
+	assert.Equal(t, 3, levenshtein("", "abc"))
+}
+
+func TestSuggestField_IgnoresDistantNames(t *testing.T) {
+	fields := yamlFields(reflect.TypeOf(Assertion{}))
+
+	assert.Equal(t, "command", suggestField("comand", fields))
+	assert.Equal(t, "", suggestField("expected", fields))
+}
@@ -2,6 +2,11 @@ package spec
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -15,9 +20,25 @@ var validOnFailure = map[string]bool{
 const invalidOnFailureMessage = "must be skip_children, continue, or abort_run"
 
 type ValidationError struct {
-	File    string
-	Path    string
-	Message string
+	File    string `json:"file"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (validationError ValidationError) String() string {
+	location := validationError.File
+	if validationError.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, validationError.Line)
+	}
+	if validationError.Column > 0 {
+		location = fmt.Sprintf("%s:%d", location, validationError.Column)
+	}
+	if validationError.Path == "" {
+		return fmt.Sprintf("%s: %s", location, validationError.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", location, validationError.Path, validationError.Message)
 }
 
 type validator struct {
@@ -49,6 +70,13 @@ func (validator *validator) checkTimeout(timeout, path string) {
 	}
 }
 
+func (validator *validator) checkRetries(retries int, retryDelay, path string) {
+	if retries < 0 {
+		validator.addError(path+".retries", "must not be negative")
+	}
+	validator.checkTimeout(retryDelay, path+".retry_delay")
+}
+
 func (validator *validator) validateHook(hook *Hook, path string) {
 	if hook == nil {
 		return
@@ -67,13 +95,156 @@ func (validator *validator) validateRunBlock(runBlock *RunBlock, path string) {
 		validator.addError(path+".command", "required")
 	}
 	validator.checkTimeout(runBlock.Timeout, path+".timeout")
+	validator.checkRetries(runBlock.Retries, runBlock.RetryDelay, path)
 }
 
 func (validator *validator) validateAssertion(assertion Assertion, path string) {
-	if assertion.Command == "" {
+	validator.validateAssertionBody(assertion, path)
+	validator.checkTimeout(assertion.Within, path+".within")
+	validator.checkTimeout(assertion.Interval, path+".interval")
+	if assertion.Within == "" && assertion.Interval != "" {
+		validator.addError(path+".interval", "requires within")
+	}
+	if assertion.Within == "" && assertion.Probe != "" {
+		validator.addError(path+".probe", "requires within")
+	}
+}
+
+func (validator *validator) validateAssertionBody(assertion Assertion, path string) {
+	forms := 0
+	for _, set := range []bool{assertion.Command != "", len(assertion.AnyOf) > 0, len(assertion.AllOf) > 0} {
+		if set {
+			forms++
+		}
+	}
+	switch {
+	case forms == 0:
 		validator.addError(path+".command", "required")
+	case forms > 1:
+		validator.addError(path, "set only one of command, any_of, all_of")
 	}
 	validator.checkTimeout(assertion.Timeout, path+".timeout")
+	validator.validateBranches(assertion.AnyOf, path+".any_of")
+	validator.validateBranches(assertion.AllOf, path+".all_of")
+}
+
+func (validator *validator) validateBranches(branches []Assertion, path string) {
+	for i, branch := range branches {
+		branchPath := fmt.Sprintf("%s[%d]", path, i)
+		validator.validateAssertionBody(branch, branchPath)
+		if branch.Within != "" {
+			validator.addError(branchPath+".within", "only allowed on top-level assertions")
+		}
+		if branch.Interval != "" {
+			validator.addError(branchPath+".interval", "only allowed on top-level assertions")
+		}
+		if branch.Probe != "" {
+			validator.addError(branchPath+".probe", "only allowed on top-level assertions")
+		}
+	}
+}
+
+func (validator *validator) validateNormalize(rules []NormalizeRule, path string) {
+	for i, rule := range rules {
+		rulePath := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case rule.Preset != "" && (rule.Pattern != "" || rule.Replace != ""):
+			validator.addError(rulePath, "set either preset or pattern, not both")
+		case rule.Preset != "":
+			if _, ok := normalizePresets[rule.Preset]; !ok {
+				validator.addError(rulePath+".preset", "must be one of "+strings.Join(NormalizePresetNames(), ", "))
+			}
+		case rule.Pattern == "":
+			validator.addError(rulePath, "must set preset or pattern")
+		default:
+			if _, err := regexp.Compile(rule.Pattern); err != nil {
+				validator.addError(rulePath+".pattern", "invalid regular expression")
+			}
+		}
+	}
+}
+
+func (validator *validator) validateMatrix(matrix []map[string]string, path string) {
+	seenRowIDs := make(map[string]bool)
+	for i, row := range matrix {
+		rowPath := fmt.Sprintf("%s.matrix[%d]", path, i)
+		rowID := MatrixRowID(row)
+		switch {
+		case rowID == "":
+			validator.addError(rowPath, "row id required")
+		case strings.ContainsAny(rowID, "/ \t\n"):
+			validator.addError(rowPath, fmt.Sprintf("row id %q cannot contain / or whitespace; set an explicit id", rowID))
+		case seenRowIDs[rowID]:
+			validator.addError(rowPath, fmt.Sprintf("duplicate row id %q", rowID))
+		}
+		seenRowIDs[rowID] = true
+	}
+}
+
+var localHosts = map[string]bool{
+	"localhost": true,
+	"127.0.0.1": true,
+	"::1":       true,
+}
+
+func (validator *validator) validateReadiness(ready *Readiness, path string) {
+	if ready == nil {
+		return
+	}
+	probes := 0
+	for _, probe := range []string{ready.TCP, ready.HTTP, ready.Log, ready.Command} {
+		if probe != "" {
+			probes++
+		}
+	}
+	if probes != 1 {
+		validator.addError(path, "must set exactly one of tcp, http, log, or command")
+	}
+	if ready.HTTP != "" && !strings.Contains(ready.HTTP, "${") {
+		parsed, err := url.Parse(ready.HTTP)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || !localHosts[parsed.Hostname()] {
+			validator.addError(path+".http", "must be an http URL on localhost")
+		}
+	}
+	if ready.TCP != "" && !strings.Contains(ready.TCP, "${") {
+		if _, _, err := net.SplitHostPort(TCPAddress(ready.TCP)); err != nil {
+			validator.addError(path+".tcp", "must be a port or host:port")
+		}
+	}
+	if ready.Log != "" {
+		if _, err := regexp.Compile(ready.Log); err != nil {
+			validator.addError(path+".log", "invalid regular expression")
+		}
+	}
+	validator.checkTimeout(ready.Interval, path+".interval")
+}
+
+func TCPAddress(address string) string {
+	if strings.Contains(address, ":") {
+		return address
+	}
+	return "localhost:" + address
+}
+
+func (validator *validator) validateServices(services []Service) {
+	seenNames := make(map[string]bool)
+	for i, service := range services {
+		path := fmt.Sprintf("services[%d]", i)
+		switch {
+		case service.Name == "":
+			validator.addError(path+".name", "required")
+		case strings.ContainsAny(service.Name, "/ \t\n"):
+			validator.addError(path+".name", "cannot contain / or whitespace")
+		case seenNames[service.Name]:
+			validator.addError(path+".name", "duplicate")
+		}
+		seenNames[service.Name] = true
+		if service.Run == "" {
+			validator.addError(path+".run", "required")
+		}
+		validator.checkTimeout(service.Timeout, path+".timeout")
+		validator.validateReadiness(service.Ready, path+".ready")
+	}
 }
 
 func isLeaf(scenario Scenario) bool {
@@ -98,6 +269,15 @@ func (validator *validator) validateScenario(scenario Scenario, path string) {
 	if isLeaf(scenario) && scenario.AfterEach != nil {
 		validator.addError(path+".after_each", "leaf scenarios cannot have after_each hooks")
 	}
+	if isLeaf(scenario) && scenario.Parallel {
+		validator.addError(path+".parallel", "leaf scenarios cannot be parallel")
+	}
+	if isGroup(scenario) && (scenario.Retries != 0 || scenario.RetryDelay != "") {
+		validator.addError(path+".retries", "groups cannot have retries")
+	}
+	validator.checkRetries(scenario.Retries, scenario.RetryDelay, path)
+	validator.validateMatrix(scenario.Matrix, path)
+	validator.validateNormalize(scenario.Normalize, path+".normalize")
 	validator.validateHook(scenario.Before, path+".before")
 	validator.validateHook(scenario.After, path+".after")
 	validator.validateRunBlock(scenario.Run, path+".run")
@@ -111,10 +291,13 @@ func (validator *validator) validateScenarios(scenarios []Scenario, basePath str
 	seenIDs := make(map[string]bool)
 	for i, scenario := range scenarios {
 		path := fmt.Sprintf("%s[%d]", basePath, i)
-		if seenIDs[scenario.ID] {
+		ids := matrixIDs(scenario)
+		if slices.ContainsFunc(ids, func(id string) bool { return seenIDs[id] }) {
 			validator.addError(path+".id", "duplicate")
 		}
-		seenIDs[scenario.ID] = true
+		for _, id := range ids {
+			seenIDs[id] = true
+		}
 		validator.validateScenario(scenario, path)
 	}
 }
@@ -126,6 +309,8 @@ func Validate(ctx *Context, filePath string) []ValidationError {
 	specValidator.validateHook(ctx.BeforeEach, "before_each")
 	specValidator.validateHook(ctx.After, "after")
 	specValidator.validateHook(ctx.AfterEach, "after_each")
+	specValidator.validateNormalize(ctx.Normalize, "normalize")
+	specValidator.validateServices(ctx.Services)
 	specValidator.validateScenarios(ctx.Scenarios, "scenarios")
 	return specValidator.errors
 }
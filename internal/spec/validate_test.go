@@ -141,6 +141,102 @@ func TestValidate_AssertionInvalidTimeout_ReturnsError(t *testing.T) {
 	assert.Contains(t, errors[0].Message, "duration")
 }
 
+func TestValidate_PolledAssertionInvalidDurations_ReturnsErrors(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:         "test",
+			Run:        &RunBlock{Command: "echo hello"},
+			Assertions: []Assertion{{Command: "assert_equals", Within: "soon", Interval: "often"}},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 2)
+	assert.Equal(t, "scenarios[0].assertions[0].within", errors[0].Path)
+	assert.Equal(t, "scenarios[0].assertions[0].interval", errors[1].Path)
+}
+
+func TestValidate_IntervalAndProbeWithoutWithin_ReturnErrors(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:         "test",
+			Run:        &RunBlock{Command: "echo hello"},
+			Assertions: []Assertion{{Command: "assert_equals", Interval: "1s", Probe: "./status.sh"}},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 2)
+	assert.Equal(t, "scenarios[0].assertions[0].interval", errors[0].Path)
+	assert.Equal(t, "requires within", errors[0].Message)
+	assert.Equal(t, "scenarios[0].assertions[0].probe", errors[1].Path)
+}
+
+func TestValidate_CombinedAssertions_ValidatesBranches(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:  "test",
+			Run: &RunBlock{Command: "echo hello"},
+			Assertions: []Assertion{
+				{Not: true, AnyOf: []Assertion{
+					{Command: "assert_contains a ${RUN_OUTPUT}/stdout"},
+					{AllOf: []Assertion{{Command: "assert_contains b ${RUN_OUTPUT}/stdout", Timeout: "later"}}},
+				}},
+			},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].assertions[0].any_of[1].all_of[0].timeout", errors[0].Path)
+}
+
+func TestValidate_AssertionWithCommandAndBranches_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:  "test",
+			Run: &RunBlock{Command: "echo hello"},
+			Assertions: []Assertion{{
+				Command: "assert_equals 0 ${RUN_OUTPUT}/exit_code",
+				AnyOf:   []Assertion{{Command: "assert_contains ok ${RUN_OUTPUT}/stdout"}},
+			}},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].assertions[0]", errors[0].Path)
+	assert.Equal(t, "set only one of command, any_of, all_of", errors[0].Message)
+}
+
+func TestValidate_PollingInsideBranch_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:  "test",
+			Run: &RunBlock{Command: "echo hello"},
+			Assertions: []Assertion{{
+				Within: "5s",
+				AllOf:  []Assertion{{Command: "assert_contains ok ${RUN_OUTPUT}/stdout", Within: "1s"}},
+			}},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].assertions[0].all_of[0].within", errors[0].Path)
+	assert.Equal(t, "only allowed on top-level assertions", errors[0].Message)
+}
+
 func TestValidate_DuplicateScenarioIDs_ReturnsError(t *testing.T) {
 	ctx := &Context{
 		Name: "Test Spec",
@@ -210,6 +306,185 @@ func TestValidate_LeafScenarioWithAfterEach_ReturnsError(t *testing.T) {
 	assert.Contains(t, errors[0].Message, "leaf")
 }
 
+func TestValidate_ParallelLeafScenario_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:       "leaf",
+			Run:      &RunBlock{Command: "echo leaf"},
+			Parallel: true,
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].parallel", errors[0].Path)
+	assert.Contains(t, errors[0].Message, "leaf")
+}
+
+func TestValidate_NegativeRetries_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:  "leaf",
+			Run: &RunBlock{Command: "echo leaf", Retries: -1},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].run.retries", errors[0].Path)
+	assert.Contains(t, errors[0].Message, "negative")
+}
+
+func TestValidate_InvalidRetryDelay_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:         "leaf",
+			Run:        &RunBlock{Command: "echo leaf"},
+			Retries:    2,
+			RetryDelay: "soon",
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].retry_delay", errors[0].Path)
+	assert.Contains(t, errors[0].Message, "invalid duration")
+}
+
+func TestValidate_GroupWithRetries_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:      "group",
+			Retries: 2,
+			Scenarios: []Scenario{
+				{ID: "leaf", Run: &RunBlock{Command: "echo leaf"}},
+			},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].retries", errors[0].Path)
+	assert.Contains(t, errors[0].Message, "groups")
+}
+
+func TestValidate_DuplicateMatrixRowID_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:  "login",
+			Run: &RunBlock{Command: "login ${ROLE}"},
+			Matrix: []map[string]string{
+				{"ROLE": "admin"},
+				{"id": "admin", "ROLE": "root"},
+			},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].matrix[1]", errors[0].Path)
+	assert.Equal(t, `duplicate row id "admin"`, errors[0].Message)
+}
+
+func TestValidate_EmptyMatrixRowID_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:     "login",
+			Run:    &RunBlock{Command: "login"},
+			Matrix: []map[string]string{{"ROLE": ""}},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].matrix[0]", errors[0].Path)
+	assert.Contains(t, errors[0].Message, "required")
+}
+
+func TestValidate_MatrixRowIDWithSlash_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:     "fetch",
+			Run:    &RunBlock{Command: "curl ${URL}"},
+			Matrix: []map[string]string{{"URL": "http://localhost/"}},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].matrix[0]", errors[0].Path)
+	assert.Contains(t, errors[0].Message, "/")
+}
+
+func TestValidate_MatrixRowIDWithWhitespace_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{
+			ID:     "greet",
+			Run:    &RunBlock{Command: "echo ${GREETING}"},
+			Matrix: []map[string]string{{"GREETING": "hello world"}},
+		}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[0].matrix[0]", errors[0].Path)
+	assert.Contains(t, errors[0].Message, "explicit id")
+}
+
+func TestValidate_ExpandedIDCollidingWithSibling_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{
+			{ID: "login[admin]", Run: &RunBlock{Command: "login admin"}},
+			{
+				ID:     "login",
+				Run:    &RunBlock{Command: "login ${ROLE}"},
+				Matrix: []map[string]string{{"ROLE": "admin"}},
+			},
+		},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "scenarios[1].id", errors[0].Path)
+	assert.Contains(t, errors[0].Message, "duplicate")
+}
+
+func TestValidationError_String(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      ValidationError
+		expected string
+	}{
+		{"with position", ValidationError{File: "context.yaml", Line: 3, Column: 7, Path: "on_failure", Message: "bad"}, "context.yaml:3:7: on_failure: bad"},
+		{"line only", ValidationError{File: "context.yaml", Line: 3, Message: "bad"}, "context.yaml:3: bad"},
+		{"no position", ValidationError{File: "context.yaml", Path: "on_failure", Message: "bad"}, "context.yaml: on_failure: bad"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.err.String())
+		})
+	}
+}
+
 func TestValidate_AfterHookWithoutRun_ReturnsError(t *testing.T) {
 	ctx := &Context{
 		Name:  "Test Spec",
@@ -298,3 +573,146 @@ func TestValidate_ScenarioAfterHookWithoutRun_ReturnsError(t *testing.T) {
 	assert.Equal(t, "scenarios[0].after.run", errors[0].Path)
 	assert.Contains(t, errors[0].Message, "required")
 }
+
+func TestValidate_ValidServices_ReturnsEmptySlice(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Services: []Service{
+			{Name: "db", Run: "start_db", Ready: &Readiness{TCP: "5432"}, Timeout: "10s"},
+			{Name: "api", Run: "start_api", Ready: &Readiness{HTTP: "http://localhost:8080/health", Interval: "50ms"}},
+			{Name: "worker", Run: "start_worker", Ready: &Readiness{Log: "ready in \\d+ms"}},
+			{Name: "cache", Run: "start_cache", Ready: &Readiness{Command: "redis-cli ping"}},
+		},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	assert.Equal(t, []ValidationError{}, errors)
+}
+
+func TestValidate_ServiceWithoutNameOrRun_ReturnsErrors(t *testing.T) {
+	ctx := &Context{Name: "Test Spec", Services: []Service{{}}}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 2)
+	assert.Equal(t, "services[0].name", errors[0].Path)
+	assert.Equal(t, "services[0].run", errors[1].Path)
+}
+
+func TestValidate_DuplicateServiceName_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name:     "Test Spec",
+		Services: []Service{{Name: "db", Run: "a"}, {Name: "db", Run: "b"}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "services[1].name", errors[0].Path)
+	assert.Equal(t, "duplicate", errors[0].Message)
+}
+
+func TestValidate_ServiceNameWithSlash_ReturnsError(t *testing.T) {
+	ctx := &Context{Name: "Test Spec", Services: []Service{{Name: "db/primary", Run: "a"}}}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "services[0].name", errors[0].Path)
+}
+
+func TestValidate_ReadinessWithMultipleProbes_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name:     "Test Spec",
+		Services: []Service{{Name: "db", Run: "a", Ready: &Readiness{TCP: "5432", Log: "ready"}}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "services[0].ready", errors[0].Path)
+}
+
+func TestValidate_ReadinessHTTPNotOnLocalhost_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name:     "Test Spec",
+		Services: []Service{{Name: "api", Run: "a", Ready: &Readiness{HTTP: "http://example.com/health"}}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "services[0].ready.http", errors[0].Path)
+}
+
+func TestValidate_ReadinessInvalidLogPattern_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name:     "Test Spec",
+		Services: []Service{{Name: "api", Run: "a", Ready: &Readiness{Log: "ready("}}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "services[0].ready.log", errors[0].Path)
+}
+
+func TestValidate_ServiceInvalidTimeout_ReturnsError(t *testing.T) {
+	ctx := &Context{
+		Name:     "Test Spec",
+		Services: []Service{{Name: "api", Run: "a", Timeout: "soon"}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "services[0].timeout", errors[0].Path)
+}
+
+func TestValidate_ValidNormalizeRules_ReturnsEmptySlice(t *testing.T) {
+	ctx := &Context{
+		Name:      "Test Spec",
+		Normalize: []NormalizeRule{{Preset: "uuids"}, {Pattern: "took [0-9]+ms", Replace: "took <N>ms"}},
+		Scenarios: []Scenario{{ID: "login", Normalize: []NormalizeRule{{Preset: "temp_paths"}}}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	assert.Equal(t, []ValidationError{}, errors)
+}
+
+func TestValidate_UnknownNormalizePreset_ReturnsError(t *testing.T) {
+	ctx := &Context{Name: "Test Spec", Normalize: []NormalizeRule{{Preset: "dates"}}}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 1)
+	assert.Equal(t, "normalize[0].preset", errors[0].Path)
+	assert.Equal(t, "must be one of pids, temp_paths, timestamps, uuids", errors[0].Message)
+}
+
+func TestValidate_InvalidNormalizeRules_ReturnErrors(t *testing.T) {
+	ctx := &Context{
+		Name: "Test Spec",
+		Scenarios: []Scenario{{ID: "login", Normalize: []NormalizeRule{
+			{Preset: "uuids", Pattern: "x"},
+			{Replace: "<N>"},
+			{Pattern: "took (", Replace: "<N>"},
+		}}},
+	}
+
+	errors := Validate(ctx, "context.yaml")
+
+	require.Len(t, errors, 3)
+	assert.Equal(t, "scenarios[0].normalize[0]", errors[0].Path)
+	assert.Equal(t, "set either preset or pattern, not both", errors[0].Message)
+	assert.Equal(t, "scenarios[0].normalize[1]", errors[1].Path)
+	assert.Equal(t, "must set preset or pattern", errors[1].Message)
+	assert.Equal(t, "scenarios[0].normalize[2].pattern", errors[2].Path)
+}
+
+func TestTCPAddress_DefaultsToLocalhost(t *testing.T) {
+	assert.Equal(t, "localhost:5432", TCPAddress("5432"))
+	assert.Equal(t, "127.0.0.1:5432", TCPAddress("127.0.0.1:5432"))
+}
@@ -1,6 +1,9 @@
 package executor
 
 import (
+	"strings"
+	"sync"
+
 	"basanos/internal/executor"
 )
 
@@ -11,21 +14,33 @@ type ExecutedCommand struct {
 }
 
 type FakeExecutor struct {
+	mutex            sync.Mutex
 	Commands         []ExecutedCommand
 	Stdout           string
 	Stderr           string
+	StdoutChunks     []string
 	DefaultExitCode  int
 	ExitCodes        map[string]int
 	TimeoutCommands  map[string]bool
 	TimeoutExitCodes map[string]int
+	LeakedProcesses  map[string][]int
+	ServiceOutput    map[string]string
+	ExitedServices   map[string]int
+	Processes        []*FakeProcess
 	StdinReceived    string
+	Interrupts       int
 }
 
 func (fake *FakeExecutor) Execute(command string, timeout string, env map[string]string) (stdout, stderr string, exitCode int, err error) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
 	fake.Commands = append(fake.Commands, ExecutedCommand{Command: command, Timeout: timeout, Env: env})
 	if fake.shouldTimeout(command) {
 		return "", "", fake.timeoutExitCode(command), executor.ErrTimeout
 	}
+	if pids, leaked := fake.LeakedProcesses[command]; leaked {
+		return fake.Stdout, fake.Stderr, fake.exitCodeFor(command), &executor.LeakError{PGID: pids[0], PIDs: pids}
+	}
 	return fake.Stdout, fake.Stderr, fake.exitCodeFor(command), nil
 }
 
@@ -53,7 +68,91 @@ func (fake *FakeExecutor) exitCodeFor(command string) int {
 	return fake.DefaultExitCode
 }
 
+func (fake *FakeExecutor) ExecuteStreaming(command string, timeout string, env map[string]string, stdin string, onOutput executor.OutputHandler) (stdout, stderr string, exitCode int, err error) {
+	if stdin != "" {
+		stdout, stderr, exitCode, err = fake.ExecuteWithStdin(command, timeout, env, stdin)
+	} else {
+		stdout, stderr, exitCode, err = fake.Execute(command, timeout, env)
+	}
+	if fake.StdoutChunks != nil {
+		stdout = strings.Join(fake.StdoutChunks, "")
+	}
+	if onOutput == nil {
+		return stdout, stderr, exitCode, err
+	}
+	for _, chunk := range fake.stdoutChunks(stdout) {
+		onOutput("stdout", chunk)
+	}
+	if stderr != "" {
+		onOutput("stderr", stderr)
+	}
+	return stdout, stderr, exitCode, err
+}
+
+func (fake *FakeExecutor) stdoutChunks(stdout string) []string {
+	if fake.StdoutChunks != nil {
+		return fake.StdoutChunks
+	}
+	if stdout == "" {
+		return nil
+	}
+	return []string{stdout}
+}
+
+type FakeProcess struct {
+	Command  string
+	Stopped  bool
+	done     chan struct{}
+	once     sync.Once
+	exitCode int
+}
+
+func (process *FakeProcess) exit(code int) {
+	process.once.Do(func() {
+		process.exitCode = code
+		close(process.done)
+	})
+}
+
+func (process *FakeProcess) Done() <-chan struct{} {
+	return process.done
+}
+
+func (process *FakeProcess) ExitCode() int {
+	<-process.done
+	return process.exitCode
+}
+
+func (process *FakeProcess) Stop() int {
+	process.Stopped = true
+	process.exit(-1)
+	return process.exitCode
+}
+
+func (fake *FakeExecutor) Start(command string, env map[string]string, onOutput executor.OutputHandler) (executor.Process, error) {
+	fake.mutex.Lock()
+	fake.Commands = append(fake.Commands, ExecutedCommand{Command: command, Env: env})
+	process := &FakeProcess{Command: command, done: make(chan struct{})}
+	fake.Processes = append(fake.Processes, process)
+	fake.mutex.Unlock()
+	if output, ok := fake.ServiceOutput[command]; ok && onOutput != nil {
+		onOutput("stdout", output)
+	}
+	if code, exited := fake.ExitedServices[command]; exited {
+		process.exit(code)
+	}
+	return process, nil
+}
+
 func (fake *FakeExecutor) ExecuteWithStdin(command string, timeout string, env map[string]string, stdin string) (stdout, stderr string, exitCode int, err error) {
+	fake.mutex.Lock()
 	fake.StdinReceived = stdin
+	fake.mutex.Unlock()
 	return fake.Execute(command, timeout, env)
 }
+
+func (fake *FakeExecutor) Interrupt() {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.Interrupts++
+}
@@ -45,6 +45,15 @@ func (m *MemoryFS) AppendFile(path string, data []byte) error {
 	return nil
 }
 
+func (m *MemoryFS) RemoveAll(path string) error {
+	for file := range m.files {
+		if file == path || strings.HasPrefix(file, path+"/") {
+			delete(m.files, file)
+		}
+	}
+	return nil
+}
+
 func (m *MemoryFS) ReadDir(path string) ([]os.DirEntry, error) {
 	if !m.dirs[path] {
 		return nil, os.ErrNotExist
@@ -20,15 +20,55 @@ func LoadContext(filesystem fs.FileSystem, dirPath string) (*spec.Context, error
 	if err != nil {
 		return nil, err
 	}
-	ctx, err := spec.ParseContext(data)
+	ctx, errors := spec.ValidateDocument(data, contextFile)
+	if len(errors) > 0 {
+		return nil, fmt.Errorf("validation failed: %s", errors[0])
+	}
+	spec.ExpandMatrix(ctx)
+	return ctx, nil
+}
+
+func childContextDirs(filesystem fs.FileSystem, dirPath string) ([]string, error) {
+	entries, err := filesystem.ReadDir(dirPath)
 	if err != nil {
 		return nil, err
 	}
-	errors := spec.Validate(ctx, contextFile)
-	if len(errors) > 0 {
-		return nil, fmt.Errorf("validation failed: %s: %s: %s", errors[0].File, errors[0].Path, errors[0].Message)
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		childFilePath := filepath.Join(dirPath, entry.Name())
+		if _, err := filesystem.Stat(filepath.Join(childFilePath, "context.yaml")); err != nil {
+			continue
+		}
+		dirs = append(dirs, childFilePath)
 	}
-	return ctx, nil
+	return dirs, nil
+}
+
+func ValidateSpecTree(filesystem fs.FileSystem, rootPath string) ([]spec.ValidationError, int, error) {
+	contextFile := filepath.Join(rootPath, "context.yaml")
+	data, err := filesystem.ReadFile(contextFile)
+	if err != nil {
+		return nil, 0, err
+	}
+	_, errors := spec.ValidateDocument(data, contextFile)
+	files := 1
+
+	childDirs, err := childContextDirs(filesystem, rootPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, childDir := range childDirs {
+		childErrors, childFiles, err := ValidateSpecTree(filesystem, childDir)
+		if err != nil {
+			return nil, 0, err
+		}
+		errors = append(errors, childErrors...)
+		files += childFiles
+	}
+	return errors, files, nil
 }
 
 func LoadSpecTreeRecursive(filesystem fs.FileSystem, rootFilePath string, rootSpecPath string) (*SpecTree, error) {
@@ -39,21 +79,13 @@ func LoadSpecTreeRecursive(filesystem fs.FileSystem, rootFilePath string, rootSp
 
 	tree := &SpecTree{Path: rootSpecPath, Context: ctx}
 
-	entries, err := filesystem.ReadDir(rootFilePath)
+	childDirs, err := childContextDirs(filesystem, rootFilePath)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-		childFilePath := filepath.Join(rootFilePath, entry.Name())
-		contextFile := filepath.Join(childFilePath, "context.yaml")
-		if _, err := filesystem.Stat(contextFile); err != nil {
-			continue
-		}
-		childSpecPath := filepath.Join(rootSpecPath, entry.Name())
+	for _, childFilePath := range childDirs {
+		childSpecPath := filepath.Join(rootSpecPath, filepath.Base(childFilePath))
 		child, err := LoadSpecTreeRecursive(filesystem, childFilePath, childSpecPath)
 		if err != nil {
 			return nil, err
@@ -81,3 +81,45 @@ scenarios:
 	assert.Contains(t, err.Error(), "scenarios[0].id")
 	assert.Contains(t, err.Error(), "required")
 }
+
+func TestLoadContext_ExpandsMatrix(t *testing.T) {
+	mfs := memfs.NewMemoryFS()
+	mfs.AddDir("/spec")
+	mfs.AddFile("/spec/context.yaml", []byte(`
+name: "Matrix"
+scenarios:
+  - id: login
+    name: "Login"
+    matrix:
+      - ROLE: admin
+      - ROLE: guest
+    run:
+      command: login ${ROLE}
+`))
+
+	ctx, err := LoadContext(mfs, "/spec")
+
+	require.NoError(t, err)
+	require.Len(t, ctx.Scenarios, 2)
+	assert.Equal(t, "login[admin]", ctx.Scenarios[0].ID)
+	assert.Equal(t, "login[guest]", ctx.Scenarios[1].ID)
+	assert.Equal(t, "guest", ctx.Scenarios[1].Env["ROLE"])
+}
+
+func TestValidateSpecTree_CollectsErrorsFromEveryContext(t *testing.T) {
+	mfs := memfs.NewMemoryFS()
+	mfs.AddDir("spec")
+	mfs.AddDir("spec/child")
+	mfs.AddFile("spec/context.yaml", []byte("name: \"Root\"\non_failure: explode\n"))
+	mfs.AddFile("spec/child/context.yaml", []byte("name: \"Child\"\nscenarios:\n  - name: \"Missing ID\"\n"))
+
+	errors, files, err := ValidateSpecTree(mfs, "spec")
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, files)
+	require.Len(t, errors, 2)
+	assert.Equal(t, "spec/context.yaml", errors[0].File)
+	assert.Equal(t, 2, errors[0].Line)
+	assert.Equal(t, "spec/child/context.yaml", errors[1].File)
+	assert.Equal(t, "scenarios[0].id", errors[1].Path)
+}
@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"basanos/internal/cmd"
 	"basanos/internal/executor"
@@ -28,34 +30,92 @@ func main() {
 		return
 	}
 
+	shellExecutor := executor.NewShellExecutor()
+	shellExecutor.KillGrace = config.KillGrace
+
+	interrupts := make(chan os.Signal, 1)
+
 	opts := cmd.RunOptions{
 		Config:     config,
 		FileSystem: fs.OSFileSystem{},
-		Executor:   executor.NewShellExecutor(),
+		Executor:   shellExecutor,
 		Stdout:     os.Stdout,
+		Interrupts: interrupts,
+	}
+
+	if config.Command == "validate" {
+		validateSpecs(opts)
+		return
+	}
+
+	if config.Command == "report" {
+		replayReport(opts)
+		return
 	}
 
+	signal.Notify(interrupts, os.Interrupt, syscall.SIGTERM)
 	result := cmd.Run(opts)
 	if result.Error != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", result.Error)
 		os.Exit(1)
 	}
+	if result.Interrupted {
+		os.Exit(130)
+	}
 	if !result.Success {
 		os.Exit(1)
 	}
 }
 
+func validateSpecs(opts cmd.RunOptions) {
+	result := cmd.Validate(opts)
+	if result.Error != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", result.Error)
+		os.Exit(1)
+	}
+	if !result.Success {
+		os.Exit(1)
+	}
+}
+
+func replayReport(opts cmd.RunOptions) {
+	result := cmd.Report(opts)
+	if result.Error != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", result.Error)
+		os.Exit(1)
+	}
+	if result.Incomplete {
+		fmt.Fprintf(os.Stderr, "Warning: %s has no run_end event; the run is reported as interrupted\n", opts.Config.From)
+		os.Exit(1)
+	}
+}
+
 func printHelp() {
 	fmt.Println(`basanos - acceptance test framework
 
 Usage: basanos [options]
+       basanos validate [-s DIR] [-o json]
+       basanos report --from FILE [-s DIR] [-o SINK]...
+
+Commands:
+  validate            Check every context.yaml in the spec tree and report all
+                      errors as file:line:col without running anything
+  report              Replay a saved -o json event stream through the given
+                      output sinks; tap also reads the spec tree from -s
 
 Options:
   -s, --spec DIR      Spec directory (default: spec)
   -o, --output SINK   Output sink (default: cli)
                       Can be specified multiple times
-                      Formats: cli, json, files, files:PATH, junit
+                      Formats: cli, json, json:v1, files, files:PATH, junit, tap,
+                      html, html:PATH
+  --from FILE         NDJSON event stream for report to replay
   -f, --filter PAT    Filter specs by path pattern
+  -j, --jobs N        Run scenarios in parallel contexts on N workers (default: 1)
+  --kill-grace DUR    Time between SIGTERM and SIGKILL when a command times out
+                      (default: 5s)
+  --slowest N         List the N slowest scenarios with a per-phase breakdown
+  --update-snapshots  Rewrite assert_snapshot files that differ from the output
   --verbose           Show context/scenario names with indentation
   -h, --help          Show this help
   -v, --version       Show version`)